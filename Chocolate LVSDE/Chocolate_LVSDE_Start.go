@@ -23,8 +23,10 @@ package main
 import "C"
 import (
 	"fmt"
-	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/EmbeddingSpecification"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/CommandLineInterface"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
 	"os"
+	"path/filepath"
 )
 
 /*
@@ -52,26 +54,12 @@ On Windows, also make sure the location of python310.dll such as C:\Users\TheUse
 */
 func main() {
 
-	fmt.Println("Chocolate LVSDE 1.20")
-	fmt.Println("An implementation of the Layered Vertex Splitting Data Embedding (LVSDE) dimensionality reduction technique.")
-	fmt.Println("Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.")
-	fmt.Println("")
-
-	args := os.Args
-
-	if len(args) == 1 {
-		fmt.Println("Please specify the absolute path for the embedding specifications file as the only argument or --structure-help for information on the structure of embedding specification file.")
-	} else if len(args) == 2 && args[1] != "--structure-help" {
-		embeddingSpecificationsFilePath := args[1]
-		embeddingSpecifications := EmbeddingSpecification.ReadEmbeddingSpecification(embeddingSpecificationsFilePath)
-		if len(embeddingSpecifications.EmbeddingSpecifications) > 1 {
-			panic("Not finished successfully")
-		}
-		EmbeddingSpecification.RunEmbeddingSpecifications(embeddingSpecifications.EmbeddingSpecifications[0:1])
-	} else if len(args) == 2 && args[1] == "--structure-help" {
-		fmt.Println("Structure of embedding specification file which is a JSON file where some of the JSON elements have default values and paths are relative to the directory containing the embedding specification file:")
-		fmt.Println("{\n\t\"embedding_specifications\":[\n\t{\n\t\t\"input_file_path\":\"\",\n\t\t\"output_directory\":\"\",\n\t\t\"is_input_file_distances\":\"\",\n\t\t\"number_of_initial_data_abstraction_units\":\"\",\n\t\t\"visual_density_adjustment_parameter\":\"\",\n\t\t\"number_of_neighbours_for_building_neighbourhood_graph\":\"\",\n\t\t\"evaluation_neighbourhood_sizes\":[],\n\t\t\"preliminary_to_thirty_dimensions_umap\":\"\",\n\t\t\"random_state\":\"\",\n\t\t\"class_labels\":[],\n\t\t\"compare_with_other_methods\":\"\",\n\t\t\"colours_list\":\"\",\n\t\t\"images_file_red_green_blue_channels\":\"\",\n\t\t\"images_file_grayscale_single_channel\":\"\",\n\t\t\"images_file_image_width\":\"\",\n\t\t\"images_file_has_class_label_numbers\":\"\",\n\t\t\"random_seed\":\"\",\n\t\t\"preliminary_to_thirty_dimensions_umap\":\"\",\n\t\t\"number_of_secondary_data_abstraction_units\":\"\",\n\t\t\"use_cosine_distance_for_input_multi_dimensional_data\":\"\"\n\t}\n\t]\n}")
-	} else {
-		fmt.Println("Incorrect number of arguments")
+	if !CommandLineInterface.IsMachineReadableOutput(os.Args[1:]) {
+		fmt.Println("Chocolate LVSDE " + DataEmbedding.VersionOfChocolateLVSDE)
+		fmt.Println("An implementation of the Layered Vertex Splitting Data Embedding (LVSDE) dimensionality reduction technique.")
+		fmt.Println("Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.")
+		fmt.Println("")
 	}
+
+	os.Exit(CommandLineInterface.Run(filepath.Base(os.Args[0]), os.Args[1:]))
 }
@@ -0,0 +1,665 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package CommandLineInterface
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/EmbeddingSpecification"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/FileReadingOrWriting"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
+	"math"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type command struct {
+	name        string
+	arguments   string
+	description string
+	run         func(programName string, flagSet *flag.FlagSet, arguments []string) int
+}
+
+var commands = []command{
+	{"run", "<embedding specifications file>", "Runs every entry of an embedding specifications file and writes a run summary.", runCommandRun},
+	{"validate", "<embedding specifications file>", "Checks every field of an embedding specifications file and scans its input and image files without running it or creating output directories.", runCommandValidate},
+	{"render", "<embedding file>", "Renders PNG images of one iteration of a saved embedding (embedding.archive, embedded_data.VCED, JSON or CSV).", runCommandRender},
+	{"evaluate", "<embedding file>", "Runs the KNN accuracy evaluation on one iteration of a saved embedding.", runCommandEvaluate},
+	{"inspect", "<embedding file>", "Prints a summary of a saved embedding.", runCommandInspect},
+	{"convert", "<input embedding file> <output embedding file>", "Converts between embedding.archive, VCED, JSON and CSV, chosen by file extension.", runCommandConvert},
+	{"schema", "", "Prints the JSON Schema of the embedding specifications file.", runCommandSchema},
+}
+
+func Run(programName string, arguments []string) int {
+	if len(arguments) == 0 {
+		printUsage(programName)
+		return 0
+	}
+
+	if arguments[0] == "--structure-help" && len(arguments) == 1 {
+		return printStructureHelp()
+	}
+
+	if arguments[0] == "help" || arguments[0] == "--help" || arguments[0] == "-h" {
+		printUsage(programName)
+		return 0
+	}
+
+	for _, command := range commands {
+		if command.name == arguments[0] {
+			flagSet := newFlagSet(programName, command)
+			return command.run(programName, flagSet, arguments[1:])
+		}
+	}
+
+	if len(arguments) == 1 && !strings.HasPrefix(arguments[0], "-") {
+		runCommand := commands[0]
+		return runCommand.run(programName, newFlagSet(programName, runCommand), arguments)
+	}
+
+	fmt.Println("Unknown command or incorrect number of arguments:", strings.Join(arguments, " "))
+	printUsage(programName)
+	return 2
+}
+
+func IsMachineReadableOutput(arguments []string) bool {
+	return len(arguments) > 0 && arguments[0] == "schema"
+}
+
+func printUsage(programName string) {
+	fmt.Println("Usage:")
+	fmt.Println("  " + programName + " <command> [flags] <arguments>")
+	fmt.Println("  " + programName + " <embedding specifications file>      (same as the run command)")
+	fmt.Println("  " + programName + " --structure-help                     (structure of the embedding specifications file)")
+	fmt.Println("")
+	fmt.Println("Commands:")
+	for _, command := range commands {
+		fmt.Printf("  %-10s %s\n", command.name, command.description)
+	}
+	fmt.Println("")
+	fmt.Println("Use " + programName + " <command> --help for the flags of a command.")
+}
+
+func printStructureHelp() int {
+	structureHelp, err := EmbeddingSpecification.StructureHelp()
+	if err != nil {
+		return reportError(err)
+	}
+	fmt.Println(structureHelp)
+	return 0
+}
+
+func newFlagSet(programName string, command command) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(command.name, flag.ContinueOnError)
+	flagSet.SetOutput(os.Stdout)
+	flagSet.Usage = func() {
+		fmt.Println("Usage: " + programName + " " + command.name + " [flags] " + command.arguments)
+		fmt.Println(command.description)
+		hasFlags := false
+		flagSet.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Println("Flags (must come before the arguments):")
+			flagSet.PrintDefaults()
+		}
+	}
+	return flagSet
+}
+
+func reportError(err error) int {
+	fmt.Println("Not finished successfully.", err)
+	return 1
+}
+
+func configureLogging(logLevel string, logFormat string, logFilePath string) (func(), error) {
+	minimumLevel, err := Logging.ParseLevel(logLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	if logFormat != "text" && logFormat != "json" {
+		return nil, fmt.Errorf("unknown log format %q, expected text or json", logFormat)
+	}
+
+	if logFilePath == "" {
+		Logging.Configure(os.Stdout, minimumLevel, logFormat == "json")
+		return func() {}, nil
+	}
+
+	logFile, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, ErrorHandling.NewFileError(logFilePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	Logging.Configure(logFile, minimumLevel, logFormat == "json")
+
+	return func() {
+		Logging.Configure(os.Stdout, minimumLevel, false)
+		logFile.Close()
+	}, nil
+}
+
+func cancelOnInterrupt() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+
+	go func() {
+		_, ok := <-interrupts
+		if ok {
+			signal.Stop(interrupts)
+			Logging.Warn("Interrupt received, stopping the run. Interrupt again to exit immediately.", nil)
+			cancel()
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(interrupts)
+		close(interrupts)
+		cancel()
+	}
+}
+
+func checkOutputDirectoryPolicy(outputDirectoryPolicy string) bool {
+	if outputDirectoryPolicy == "" || EmbeddingSpecification.IsValidOutputDirectoryPolicy(outputDirectoryPolicy) {
+		return true
+	}
+
+	fmt.Fprintln(os.Stderr, "Invalid output directory policy:", outputDirectoryPolicy+". Valid policies are", strings.Join(EmbeddingSpecification.OutputDirectoryPolicies, ", ")+".")
+	return false
+}
+
+const fieldOverridesFlagUsage = "overrides a field of the embedding specifications, as [index:]field=value where index selects one embedding specification, list values are comma separated and relative paths are relative to the current directory (can be repeated, also LVSDE_<FIELD> environment variables)"
+
+type fieldOverridesFlag []EmbeddingSpecification.FieldOverride
+
+func (fieldOverrides *fieldOverridesFlag) String() string {
+	if fieldOverrides == nil {
+		return ""
+	}
+	arguments := make([]string, len(*fieldOverrides))
+	for i, fieldOverride := range *fieldOverrides {
+		arguments[i] = fieldOverride.FieldName + "=" + fieldOverride.Value
+	}
+	return strings.Join(arguments, " ")
+}
+
+func (fieldOverrides *fieldOverridesFlag) Set(argument string) error {
+	fieldOverride, err := EmbeddingSpecification.ParseFieldOverride(argument)
+	if err != nil {
+		return err
+	}
+	*fieldOverrides = append(*fieldOverrides, fieldOverride)
+	return nil
+}
+
+func environmentAndCommandLineFieldOverrides(commandLineFieldOverrides fieldOverridesFlag) []EmbeddingSpecification.FieldOverride {
+	fieldOverrides, unknownVariableNames := EmbeddingSpecification.FieldOverridesFromEnvironment(os.Environ())
+	for _, unknownVariableName := range unknownVariableNames {
+		Logging.Warn("Ignoring environment variable which does not name a field of the embedding specifications.", Logging.Fields{"environment_variable": unknownVariableName})
+	}
+	return append(fieldOverrides, commandLineFieldOverrides...)
+}
+
+func parseFlags(flagSet *flag.FlagSet, arguments []string, numberOfPositionalArguments int) (int, bool) {
+	err := flagSet.Parse(arguments)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0, false
+	}
+	if err != nil {
+		return 2, false
+	}
+
+	if flagSet.NArg() != numberOfPositionalArguments {
+		fmt.Println("Incorrect number of arguments")
+		flagSet.Usage()
+		return 2, false
+	}
+
+	return 0, true
+}
+
+func runCommandRun(programName string, flagSet *flag.FlagSet, arguments []string) int {
+	runSummaryFilePath := flagSet.String("summary-file", "", "path of the run summary CSV file (default: <embedding specifications file>_run_summary.csv)")
+	logLevel := flagSet.String("log-level", "info", "minimum level of log lines: debug, info, warn or error")
+	logFormat := flagSet.String("log-format", "text", "format of log lines: text or json (one JSON object per line)")
+	logFilePath := flagSet.String("log-file", "", "if set, log lines are appended to this file instead of the standard output")
+	outputDirectoryPolicy := flagSet.String("output-directory-policy", "", "if set, overrides output_directory_policy of every embedding specification: "+strings.Join(EmbeddingSpecification.OutputDirectoryPolicies, ", "))
+	var fieldOverrides fieldOverridesFlag
+	flagSet.Var(&fieldOverrides, "set", fieldOverridesFlagUsage)
+	if exitCode, ok := parseFlags(flagSet, arguments, 1); !ok {
+		return exitCode
+	}
+	if !checkOutputDirectoryPolicy(*outputDirectoryPolicy) {
+		return 2
+	}
+
+	closeLogFile, err := configureLogging(*logLevel, *logFormat, *logFilePath)
+	if err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	defer closeLogFile()
+
+	embeddingSpecificationsFilePath := flagSet.Arg(0)
+	embeddingSpecifications, err := EmbeddingSpecification.ReadEmbeddingSpecification(embeddingSpecificationsFilePath)
+	if err != nil {
+		return reportError(err)
+	}
+	EmbeddingSpecification.OverrideOutputDirectoryPolicy(&embeddingSpecifications, *outputDirectoryPolicy)
+	problems := EmbeddingSpecification.ApplyFieldOverrides(&embeddingSpecifications, environmentAndCommandLineFieldOverrides(fieldOverrides))
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Println(problem.String())
+		}
+		return reportError(problems[0])
+	}
+
+	ctx, cancel := cancelOnInterrupt()
+	runSummaries, runErr := EmbeddingSpecification.RunEmbeddingSpecifications(ctx, embeddingSpecifications.EmbeddingSpecifications)
+	cancel()
+
+	if *runSummaryFilePath == "" {
+		*runSummaryFilePath = strings.TrimSuffix(embeddingSpecificationsFilePath, filepath.Ext(embeddingSpecificationsFilePath)) + "_run_summary.csv"
+	}
+
+	fmt.Println("")
+	fmt.Println("Run summary:")
+	fmt.Print(EmbeddingSpecification.FormatRunSummaryTable(runSummaries))
+	err = EmbeddingSpecification.WriteRunSummaryFile(*runSummaryFilePath, runSummaries)
+	if err != nil {
+		return reportError(err)
+	}
+	fmt.Println("Run summary written to", *runSummaryFilePath)
+
+	if runErr != nil {
+		return reportError(runErr)
+	}
+
+	return 0
+}
+
+func runCommandValidate(programName string, flagSet *flag.FlagSet, arguments []string) int {
+	outputDirectoryPolicy := flagSet.String("output-directory-policy", "", "if set, validates as if output_directory_policy of every embedding specification was this policy: "+strings.Join(EmbeddingSpecification.OutputDirectoryPolicies, ", "))
+	var fieldOverrides fieldOverridesFlag
+	flagSet.Var(&fieldOverrides, "set", fieldOverridesFlagUsage)
+	if exitCode, ok := parseFlags(flagSet, arguments, 1); !ok {
+		return exitCode
+	}
+	if !checkOutputDirectoryPolicy(*outputDirectoryPolicy) {
+		return 2
+	}
+
+	embeddingSpecifications, problems := EmbeddingSpecification.ValidateEmbeddingSpecificationsFile(flagSet.Arg(0), *outputDirectoryPolicy, environmentAndCommandLineFieldOverrides(fieldOverrides))
+	for _, problem := range problems {
+		fmt.Println(problem.String())
+	}
+
+	if len(problems) > 0 {
+		fmt.Println(len(problems), "problem(s) found in", flagSet.Arg(0))
+		return 1
+	}
+
+	fmt.Println("No problems found in", flagSet.Arg(0)+",", len(embeddingSpecifications.EmbeddingSpecifications), "embedding specification(s) checked.")
+	return 0
+}
+
+func runCommandRender(programName string, flagSet *flag.FlagSet, arguments []string) int {
+	outputDirectory := flagSet.String("output-directory", "", "directory for the PNG files (default: the directory of the embedding file)")
+	iteration := flagSet.Int("iteration", 0, "one-based iteration number to render (default: the last iteration)")
+	colourings := flagSet.String("colourings", "", "comma separated colourings to render, 0 to 4 (default: 0,1,2 and 3,4 when the embedding has images)")
+	if exitCode, ok := parseFlags(flagSet, arguments, 1); !ok {
+		return exitCode
+	}
+
+	embeddingFilePath := flagSet.Arg(0)
+	embeddingDetails, err := FileReadingOrWriting.ReadEmbeddingDetailsFromFile(embeddingFilePath)
+	if err != nil {
+		return reportError(err)
+	}
+	iterationIndex, ok := resolveIterationIndex(&embeddingDetails, *iteration)
+	if !ok {
+		return 1
+	}
+
+	if *outputDirectory == "" {
+		*outputDirectory = filepath.Dir(embeddingFilePath)
+	}
+
+	hasImages := embeddingDetails.ImagesGrayscaleSingleChannel != nil || embeddingDetails.ImagesRedGreenBlueChannels != nil
+	colouringsToRender := []int32{0, 1, 2}
+	if hasImages {
+		colouringsToRender = append(colouringsToRender, 3, 4)
+	}
+	if *colourings != "" {
+		colouringsToRender = make([]int32, 0)
+		for _, colouringText := range strings.Split(*colourings, ",") {
+			colouring, err := strconv.ParseInt(strings.TrimSpace(colouringText), 10, 32)
+			if err != nil || colouring < 0 || colouring > 4 {
+				fmt.Println("Incorrect colouring:", colouringText)
+				return 2
+			}
+			if colouring > 2 && !hasImages {
+				fmt.Println("Colouring", colouring, "needs images but the embedding has none.")
+				return 1
+			}
+			colouringsToRender = append(colouringsToRender, int32(colouring))
+		}
+	}
+
+	dataAbstractionSet, err := embeddingDetails.ToDataAbstractionSet()
+	if err != nil {
+		return reportError(ErrorHandling.NewFileError(embeddingFilePath, err, ""))
+	}
+	renderShufflingSeed, _ := DataEmbedding.ShufflingSeedsOfEmbeddingDetails(&embeddingDetails)
+	coloursList := coloursListForRendering(&embeddingDetails)
+	if coloursList == nil {
+		return 1
+	}
+
+	err = os.MkdirAll(*outputDirectory, FileReadingOrWriting.Chmod)
+	if err != nil {
+		return reportError(err)
+	}
+	for _, colouring := range colouringsToRender {
+		filePath := filepath.Join(*outputDirectory, fmt.Sprintf("iteration_%04d_colouring_%d.png", iterationIndex+1, colouring))
+		err = FileReadingOrWriting.WriteEmbeddingToFile(embeddingDetails.EmbeddingIterations[iterationIndex], filePath, colouring, &dataAbstractionSet, coloursList, renderShufflingSeed)
+		if err != nil {
+			return reportError(err)
+		}
+		fmt.Println("Written", filePath)
+	}
+
+	return 0
+}
+
+func runCommandEvaluate(programName string, flagSet *flag.FlagSet, arguments []string) int {
+	neighbourhoodSizes := flagSet.String("neighbourhood-sizes", "", "comma separated evaluation neighbourhood sizes, for example 5,10,20 (required)")
+	iteration := flagSet.Int("iteration", 0, "one-based iteration number to evaluate (default: the last iteration)")
+	embeddingTechniqueName := flagSet.String("embedding-technique-name", "", "name of the embedding technique in the report (default: LVSDE for red and gray layers, otherwise the file name)")
+	outputDirectory := flagSet.String("output-directory", "", "if set, report.csv and confusionMatrices.txt are written to this directory")
+	if exitCode, ok := parseFlags(flagSet, arguments, 1); !ok {
+		return exitCode
+	}
+
+	if *neighbourhoodSizes == "" {
+		fmt.Println("The --neighbourhood-sizes flag is required.")
+		flagSet.Usage()
+		return 2
+	}
+
+	embeddingFilePath := flagSet.Arg(0)
+	embeddingDetails, err := FileReadingOrWriting.ReadEmbeddingDetailsFromFile(embeddingFilePath)
+	if err != nil {
+		return reportError(err)
+	}
+	iterationIndex, ok := resolveIterationIndex(&embeddingDetails, *iteration)
+	if !ok {
+		return 1
+	}
+
+	embeddingIteration := embeddingDetails.EmbeddingIterations[iterationIndex]
+	if *embeddingTechniqueName == "" {
+		*embeddingTechniqueName = filepath.Base(embeddingFilePath)
+		for _, dataAbstractionUnitVisibility := range embeddingIteration {
+			if dataAbstractionUnitVisibility.Layer == "red" || dataAbstractionUnitVisibility.Layer == "gray" {
+				*embeddingTechniqueName = "LVSDE"
+				break
+			}
+		}
+	}
+
+	_, evaluationTieBreakingSeed := DataEmbedding.ShufflingSeedsOfEmbeddingDetails(&embeddingDetails)
+	report := strings.Builder{}
+	confusionMatrices := strings.Builder{}
+	report.WriteString("Statistical_evaluation_type, Evaluation_neighbourhood_size, Embedding technique, Percent (rounded to 3 decimal places), Correct, Incorrects\r\n")
+
+	for _, neighbourhoodSizeText := range strings.Split(*neighbourhoodSizes, ",") {
+		neighbourhoodSize, err := strconv.Atoi(strings.TrimSpace(neighbourhoodSizeText))
+		if err != nil || neighbourhoodSize <= 0 {
+			fmt.Println("Incorrect neighbourhood size:", neighbourhoodSizeText)
+			return 2
+		}
+		_, err = DataEmbedding.EvaluateEmbeddingForReport(embeddingIteration, neighbourhoodSize, *embeddingTechniqueName, &report, &confusionMatrices, evaluationTieBreakingSeed)
+		if err != nil {
+			return reportError(err)
+		}
+	}
+
+	fmt.Print(strings.ReplaceAll(report.String(), "\r\n", "\n"))
+
+	if *outputDirectory != "" {
+		err = os.MkdirAll(*outputDirectory, FileReadingOrWriting.Chmod)
+		if err != nil {
+			return reportError(err)
+		}
+		err = FileReadingOrWriting.WriteFile(filepath.Join(*outputDirectory, "report.csv"), []byte(report.String()))
+		if err != nil {
+			return reportError(err)
+		}
+		err = FileReadingOrWriting.WriteFile(filepath.Join(*outputDirectory, "confusionMatrices.txt"), []byte(confusionMatrices.String()))
+		if err != nil {
+			return reportError(err)
+		}
+	}
+
+	return 0
+}
+
+func runCommandInspect(programName string, flagSet *flag.FlagSet, arguments []string) int {
+	if exitCode, ok := parseFlags(flagSet, arguments, 1); !ok {
+		return exitCode
+	}
+
+	embeddingFilePath := flagSet.Arg(0)
+	fmt.Println("File:", embeddingFilePath)
+
+	if strings.ToLower(filepath.Ext(embeddingFilePath)) == ".vced" {
+		embeddedData, err := FileReadingOrWriting.ReadEmbeddedDataFile(embeddingFilePath)
+		if err != nil {
+			return reportError(err)
+		}
+		fmt.Println("File format:", embeddedData.FileFormat, embeddedData.FileStructureVersion)
+		fmt.Println("Embedding method:", embeddedData.EmbeddingMethodName)
+		fmt.Println("Embedding method parameters:", embeddedData.EmbeddingMethodParameters)
+		fmt.Println("Data set name:", embeddedData.DataSetName)
+		fmt.Println("Layer names:", strings.Join(embeddedData.LayerNames, ", "))
+	}
+
+	embeddingDetails, err := FileReadingOrWriting.ReadEmbeddingDetailsFromFile(embeddingFilePath)
+	if err != nil {
+		return reportError(err)
+	}
+	if len(embeddingDetails.EmbeddingIterations) == 0 {
+		fmt.Println("The embedding has no iterations.")
+		return 1
+	}
+
+	if embeddingDetails.VersionOfUsedChocolateLVSDE != "" {
+		fmt.Println("Version of used Chocolate LVSDE:", embeddingDetails.VersionOfUsedChocolateLVSDE)
+	}
+	lastIteration := embeddingDetails.EmbeddingIterations[len(embeddingDetails.EmbeddingIterations)-1]
+	fmt.Println("Number of data abstraction units:", len(lastIteration))
+	fmt.Println("Number of iterations:", len(embeddingDetails.EmbeddingIterations))
+
+	numberOfProjections := 0
+	numberOfSplitDataAbstractionUnits := 0
+	layerCounts := make(map[string]int)
+	classLabelNumberCounts := make(map[int32]int)
+	xLow, xHigh, yLow, yHigh := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, dataAbstractionUnitVisibility := range lastIteration {
+		layerCounts[dataAbstractionUnitVisibility.Layer]++
+		classLabelNumberCounts[dataAbstractionUnitVisibility.ClassLabelNumber]++
+		numberOfProjections += len(dataAbstractionUnitVisibility.VisualSpaceCoordinates)
+		if len(dataAbstractionUnitVisibility.VisualSpaceCoordinates) > 1 {
+			numberOfSplitDataAbstractionUnits++
+		}
+		for _, visualSpaceCoordinates := range dataAbstractionUnitVisibility.VisualSpaceCoordinates {
+			xLow = math.Min(xLow, visualSpaceCoordinates[0])
+			xHigh = math.Max(xHigh, visualSpaceCoordinates[0])
+			yLow = math.Min(yLow, visualSpaceCoordinates[1])
+			yHigh = math.Max(yHigh, visualSpaceCoordinates[1])
+		}
+	}
+
+	layers := make([]string, 0, len(layerCounts))
+	for layer := range layerCounts {
+		layers = append(layers, layer)
+	}
+	sort.Strings(layers)
+	fmt.Println("Last iteration:")
+	for _, layer := range layers {
+		fmt.Printf("  data abstraction units in %s layer: %d\n", layer, layerCounts[layer])
+	}
+	fmt.Println("  visual space projections:", numberOfProjections)
+	fmt.Println("  data abstraction units with more than one projection:", numberOfSplitDataAbstractionUnits)
+	fmt.Printf("  bounding box: x from %g to %g, y from %g to %g\n", xLow, xHigh, yLow, yHigh)
+
+	classLabelNumbers := make([]int, 0, len(classLabelNumberCounts))
+	for classLabelNumber := range classLabelNumberCounts {
+		classLabelNumbers = append(classLabelNumbers, int(classLabelNumber))
+	}
+	sort.Ints(classLabelNumbers)
+	fmt.Println("Class label numbers:")
+	for _, classLabelNumber := range classLabelNumbers {
+		classLabel := ""
+		if classLabelNumber >= 0 && classLabelNumber < len(embeddingDetails.ClassLabels) {
+			classLabel = " (" + embeddingDetails.ClassLabels[classLabelNumber] + ")"
+		}
+		fmt.Printf("  %d%s: %d data abstraction units\n", classLabelNumber, classLabel, classLabelNumberCounts[int32(classLabelNumber)])
+	}
+
+	printIfSet := func(name string, value string) {
+		if value != "" {
+			fmt.Println(name+":", value)
+		}
+	}
+	printIfSet("Number of initial data abstraction units", embeddingDetails.NumberOfInitialDataAbstractionUnits)
+	printIfSet("Number of secondary data abstraction units", embeddingDetails.NumberOfSecondaryDataAbstractionUnits)
+	printIfSet("Visual density adjustment parameter", embeddingDetails.VisualDensityAdjustmentParameter)
+	printIfSet("Number of neighbours for building neighbourhood graph", embeddingDetails.NumberOfNeighboursForBuildingNeighbourhoodGraph)
+	printIfSet("Preliminary to thirty dimensions UMAP", embeddingDetails.PreliminaryToThirtyDimensionsUMAP)
+	printIfSet("Random seed", embeddingDetails.RandomSeed)
+	printIfSet("Random state", embeddingDetails.RandomState)
+	printIfSet("Master seed", embeddingDetails.MasterSeed)
+	printIfSet("Evaluation neighbourhood sizes", strings.Join(embeddingDetails.EvaluationNeighbourhoodSizes, ", "))
+	printIfSet("Colours list", strings.Join(embeddingDetails.ColoursList, ", "))
+
+	if embeddingDetails.ImagesRedGreenBlueChannels != nil {
+		fmt.Println("Images: red green blue channels, image width", embeddingDetails.ImageWidth)
+	} else if embeddingDetails.ImagesGrayscaleSingleChannel != nil {
+		fmt.Println("Images: grayscale single channel, image width", embeddingDetails.ImageWidth)
+	} else {
+		fmt.Println("Images: none")
+	}
+
+	return 0
+}
+
+func runCommandConvert(programName string, flagSet *flag.FlagSet, arguments []string) int {
+	iteration := flagSet.Int("iteration", 0, "one-based iteration number written to CSV files (default: the last iteration)")
+	if exitCode, ok := parseFlags(flagSet, arguments, 2); !ok {
+		return exitCode
+	}
+
+	embeddingDetails, err := FileReadingOrWriting.ReadEmbeddingDetailsFromFile(flagSet.Arg(0))
+	if err != nil {
+		return reportError(err)
+	}
+	iterationIndex, ok := resolveIterationIndex(&embeddingDetails, *iteration)
+	if !ok {
+		return 1
+	}
+
+	err = FileReadingOrWriting.WriteEmbeddingDetailsToFile(flagSet.Arg(1), &embeddingDetails, iterationIndex)
+	if err != nil {
+		return reportError(err)
+	}
+	fmt.Println("Written", flagSet.Arg(1))
+	return 0
+}
+
+func resolveIterationIndex(embeddingDetails *DataAbstraction.EmbeddingDetails, iteration int) (int, bool) {
+	numberOfIterations := len(embeddingDetails.EmbeddingIterations)
+	if numberOfIterations == 0 {
+		fmt.Println("The embedding has no iterations.")
+		return 0, false
+	}
+
+	if iteration == 0 {
+		return numberOfIterations - 1, true
+	}
+
+	if iteration < 1 || iteration > numberOfIterations {
+		fmt.Println("Iteration", iteration, "is out of range, the embedding has", numberOfIterations, "iterations.")
+		return 0, false
+	}
+
+	return iteration - 1, true
+}
+
+func coloursListForRendering(embeddingDetails *DataAbstraction.EmbeddingDetails) []string {
+	var maximumClassLabelNumber int32 = 0
+	for _, dataAbstractionUnitVisibility := range embeddingDetails.EmbeddingIterations[0] {
+		if dataAbstractionUnitVisibility.ClassLabelNumber > maximumClassLabelNumber {
+			maximumClassLabelNumber = dataAbstractionUnitVisibility.ClassLabelNumber
+		}
+	}
+
+	coloursList := embeddingDetails.ColoursList
+	if coloursList == nil {
+		coloursList = EmbeddingSpecification.DefaultColoursList
+	}
+
+	if int(maximumClassLabelNumber) >= len(coloursList) {
+		fmt.Println("Not enough colours for class label number", maximumClassLabelNumber)
+		return nil
+	}
+
+	return coloursList
+}
+
+func runCommandSchema(programName string, flagSet *flag.FlagSet, arguments []string) int {
+	outputFilePath := flagSet.String("output", "", "if set, the JSON Schema is written to this file instead of the standard output")
+	if exitCode, ok := parseFlags(flagSet, arguments, 0); !ok {
+		return exitCode
+	}
+
+	schemaBytes, err := EmbeddingSpecification.JSONSchema()
+	if err != nil {
+		return reportError(err)
+	}
+
+	if *outputFilePath == "" {
+		fmt.Println(string(schemaBytes))
+		return 0
+	}
+
+	err = FileReadingOrWriting.WriteFile(*outputFilePath, append(schemaBytes, '\n'))
+	if err != nil {
+		return reportError(err)
+	}
+	return 0
+}
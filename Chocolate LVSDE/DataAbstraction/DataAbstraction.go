@@ -18,10 +18,14 @@
 package DataAbstraction
 
 import (
-	"github.com/emirpasic/gods/queues/priorityqueue"
+	"context"
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
 	"image"
 	"image/color"
 	"math"
+	"math/rand"
+	"sort"
 	"strings"
 )
 
@@ -52,6 +56,8 @@ type DataAbstractionSet struct {
 	DistancesBeforeTransformation        [][]float64
 	DistancesAfterTransformation         [][]float64
 	DistancesBeforeThirtyDimensionalUMAP [][]float64
+	DistancesBeforeTransformationSource  string
+	SparseDistancesAfterTransformation   *SparseDistancesAfterTransformation
 }
 
 type DataAbstractionUnitVisibility struct {
@@ -69,6 +75,7 @@ type EmbeddingDetails struct {
 	ImageWidth                                      int32                              `json:"image_width" bson:"image_width"`
 	RandomSeed                                      string                             `json:"random_seed" bson:"random_seed"`
 	RandomState                                     string                             `json:"random_state" bson:"random_state"`
+	MasterSeed                                      string                             `json:"master_seed,omitempty" bson:"master_seed,omitempty"`
 	PreliminaryToThirtyDimensionsUMAP               string                             `json:"preliminary_to_thirty_dimensions_umap" bson:"preliminary_to_thirty_dimensions_umap"`
 	NumberOfInitialDataAbstractionUnits             string                             `json:"number_of_initial_data_abstraction_units" bson:"number_of_initial_data_abstraction_units"`
 	NumberOfSecondaryDataAbstractionUnits           string                             `json:"number_of_secondary_data_abstraction_units" bson:"number_of_secondary_data_abstraction_units"`
@@ -78,6 +85,13 @@ type EmbeddingDetails struct {
 	ImagesRedGreenBlueChannels                      [][]uint8                          `json:"images_red_green_blue_channels" bson:"images_red_green_blue_channels"`
 	ImagesGrayscaleSingleChannel                    [][]uint8                          `json:"images_grayscale_single_channel" bson:"images_grayscale_single_channel"`
 	VersionOfUsedChocolateLVSDE                     string                             `json:"version_of_used_chocolate_lvsde" bson:"version_of_used_chocolate_lvsde"`
+	IsCancelled                                     bool                               `json:"is_cancelled,omitempty" bson:"is_cancelled,omitempty"`
+	LastCompletedIteration                          int32                              `json:"last_completed_iteration,omitempty" bson:"last_completed_iteration,omitempty"`
+	NumbersOfIterationsOfPhases                     []int32                            `json:"numbers_of_iterations_of_phases,omitempty" bson:"numbers_of_iterations_of_phases,omitempty"`
+	MultilevelNumbersOfDataAbstractionUnits         []int32                            `json:"multilevel_numbers_of_data_abstraction_units,omitempty" bson:"multilevel_numbers_of_data_abstraction_units,omitempty"`
+	LastIterationsOfPhases                          []int32                            `json:"last_iterations_of_phases,omitempty" bson:"last_iterations_of_phases,omitempty"`
+	Temperatures                                    []float64                          `json:"temperatures,omitempty" bson:"temperatures,omitempty"`
+	OriginalDataAbstractionUnitNumbers              []int32                            `json:"original_data_abstraction_unit_numbers,omitempty" bson:"original_data_abstraction_unit_numbers,omitempty"`
 }
 
 type HyperDataAbstractionUnits struct {
@@ -158,9 +172,11 @@ func (dataAbstractionSet *DataAbstractionSet) SetDefaultValues(numberOfDataAbstr
 	dataAbstractionSet.DistancesBeforeTransformation = nil
 	dataAbstractionSet.DistancesAfterTransformation = nil
 	dataAbstractionSet.DistancesBeforeThirtyDimensionalUMAP = nil
+	dataAbstractionSet.DistancesBeforeTransformationSource = ""
+	dataAbstractionSet.SparseDistancesAfterTransformation = nil
 }
 
-func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesBeforeTransformationEuclidean() {
+func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesBeforeTransformationEuclidean(ctx context.Context) error {
 	numberOfDataAbstractionUnits := len(dataAbstractionSet.DataAbstractionUnits)
 	dataAbstractionSet.DistancesBeforeTransformation = make([][]float64, numberOfDataAbstractionUnits)
 
@@ -169,24 +185,24 @@ func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesBeforeTransformati
 	}
 
 	for i := 0; i < numberOfDataAbstractionUnits; i++ {
+		if ctx.Err() != nil {
+			return ErrorHandling.NewCancellationError("computing distances before transformation", 0, ctx.Err())
+		}
 		for j := 0; j < numberOfDataAbstractionUnits; j++ {
 			if i == j {
 				dataAbstractionSet.DistancesBeforeTransformation[i][j] = 0
 				continue
 			}
 
-			var distance float64 = 0
-			for k := 0; k < len(dataAbstractionSet.DataAbstractionUnits[i].OriginalSpaceCoordinates); k++ {
-				distance += math.Pow(dataAbstractionSet.DataAbstractionUnits[i].OriginalSpaceCoordinates[k]-dataAbstractionSet.DataAbstractionUnits[j].OriginalSpaceCoordinates[k], 2)
-			}
-			distance = math.Sqrt(distance)
-
-			dataAbstractionSet.DistancesBeforeTransformation[i][j] = distance
+			dataAbstractionSet.DistancesBeforeTransformation[i][j] = EuclideanDistance(dataAbstractionSet.DataAbstractionUnits[i].OriginalSpaceCoordinates, dataAbstractionSet.DataAbstractionUnits[j].OriginalSpaceCoordinates)
 		}
 	}
+
+	dataAbstractionSet.DistancesBeforeTransformationSource = DistanceSourceOriginalSpaceEuclidean
+	return nil
 }
 
-func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesBeforeTransformationCosine() {
+func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesBeforeTransformationCosine(ctx context.Context) error {
 	numberOfDataAbstractionUnits := len(dataAbstractionSet.DataAbstractionUnits)
 	dataAbstractionSet.DistancesBeforeTransformation = make([][]float64, numberOfDataAbstractionUnits)
 
@@ -195,30 +211,24 @@ func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesBeforeTransformati
 	}
 
 	for i := 0; i < numberOfDataAbstractionUnits; i++ {
+		if ctx.Err() != nil {
+			return ErrorHandling.NewCancellationError("computing distances before transformation", 0, ctx.Err())
+		}
 		for j := 0; j < numberOfDataAbstractionUnits; j++ {
 			if i == j {
 				dataAbstractionSet.DistancesBeforeTransformation[i][j] = 0
 				continue
 			}
 
-			var t1, t2, t3 float64 = 0, 0, 0
-			for k := 0; k < len(dataAbstractionSet.DataAbstractionUnits[i].OriginalSpaceCoordinates); k++ {
-				t1 += dataAbstractionSet.DataAbstractionUnits[i].OriginalSpaceCoordinates[k] * dataAbstractionSet.DataAbstractionUnits[j].OriginalSpaceCoordinates[k]
-				t2 += math.Pow(dataAbstractionSet.DataAbstractionUnits[i].OriginalSpaceCoordinates[k], 2)
-				t3 += math.Pow(dataAbstractionSet.DataAbstractionUnits[j].OriginalSpaceCoordinates[k], 2)
-			}
-
-			var distance float64 = 1.0 - t1/(math.Sqrt(t2)*math.Sqrt(t3))
-			if math.Abs(t1) < 1e-6 {
-				distance = 1.0
-			}
-
-			dataAbstractionSet.DistancesBeforeTransformation[i][j] = distance
+			dataAbstractionSet.DistancesBeforeTransformation[i][j] = CosineDistance(dataAbstractionSet.DataAbstractionUnits[i].OriginalSpaceCoordinates, dataAbstractionSet.DataAbstractionUnits[j].OriginalSpaceCoordinates)
 		}
 	}
+
+	dataAbstractionSet.DistancesBeforeTransformationSource = DistanceSourceOriginalSpaceCosine
+	return nil
 }
 
-func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesBeforeTransformationFromThirtyDimensionalSpaceEuclidean() {
+func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesBeforeTransformationFromThirtyDimensionalSpaceEuclidean(ctx context.Context) error {
 	numberOfDataAbstractionUnits := len(dataAbstractionSet.DataAbstractionUnits)
 	dataAbstractionSet.DistancesBeforeTransformation = make([][]float64, numberOfDataAbstractionUnits)
 
@@ -227,26 +237,55 @@ func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesBeforeTransformati
 	}
 
 	for i := 0; i < numberOfDataAbstractionUnits; i++ {
+		if ctx.Err() != nil {
+			return ErrorHandling.NewCancellationError("computing distances before transformation", 0, ctx.Err())
+		}
 		for j := 0; j < numberOfDataAbstractionUnits; j++ {
 			if i == j {
 				dataAbstractionSet.DistancesBeforeTransformation[i][j] = 0
 				continue
 			}
 
-			var distance float64 = 0
-			for k := 0; k < len(dataAbstractionSet.DataAbstractionUnits[i].ThirtyDimensionalSpaceCoordinates); k++ {
-				distance += math.Pow(dataAbstractionSet.DataAbstractionUnits[i].ThirtyDimensionalSpaceCoordinates[k]-dataAbstractionSet.DataAbstractionUnits[j].ThirtyDimensionalSpaceCoordinates[k], 2)
-			}
-			distance = math.Sqrt(distance)
-
-			dataAbstractionSet.DistancesBeforeTransformation[i][j] = distance
+			dataAbstractionSet.DistancesBeforeTransformation[i][j] = EuclideanDistance(dataAbstractionSet.DataAbstractionUnits[i].ThirtyDimensionalSpaceCoordinates, dataAbstractionSet.DataAbstractionUnits[j].ThirtyDimensionalSpaceCoordinates)
 		}
 	}
+
+	dataAbstractionSet.DistancesBeforeTransformationSource = DistanceSourceThirtyDimensionalSpaceEuclidean
+	return nil
 }
 
-func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesAfterTransformation() {
+func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesAfterTransformation(ctx context.Context, nearestNeighbourSearch NearestNeighbourSearch) error {
 	numberOfDataAbstractionUnits := int32(len(dataAbstractionSet.DataAbstractionUnits))
 
+	if numberOfDataAbstractionUnits <= NumberOfNeighboursForDistanceTransformation {
+		return fmt.Errorf("%w: %d data abstraction units found but at least %d are needed", ErrorHandling.ErrNotEnoughDataAbstractionUnits, numberOfDataAbstractionUnits, NumberOfNeighboursForDistanceTransformation+1)
+	}
+
+	if nearestNeighbourSearch == nil {
+		nearestNeighbourSearch = ExactNearestNeighbourSearch{}
+	}
+
+	nearestNeighbours, err := nearestNeighbourSearch.FindNearestNeighbours(ctx, dataAbstractionSet, NumberOfNeighboursForDistanceTransformation)
+	if err != nil {
+		return err
+	}
+
+	return dataAbstractionSet.ComputeDistancesAfterTransformationFromNearestNeighbours(ctx, nearestNeighbours)
+}
+
+func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesAfterTransformationFromNearestNeighbours(ctx context.Context, nearestNeighbours [][]Neighbour) error {
+	numberOfDataAbstractionUnits := int32(len(dataAbstractionSet.DataAbstractionUnits))
+
+	m, err := dataAbstractionSet.ComputeTransformationFactors(nearestNeighbours)
+	if err != nil {
+		return err
+	}
+
+	distanceBeforeTransformation := dataAbstractionSet.DistanceBeforeTransformationFunction()
+	if distanceBeforeTransformation == nil {
+		return fmt.Errorf("%w: neither coordinates nor distances before transformation are available", ErrorHandling.ErrInvalidInput)
+	}
+
 	var i, j int32
 
 	dataAbstractionSet.DistancesAfterTransformation = make([][]float64, numberOfDataAbstractionUnits)
@@ -255,48 +294,17 @@ func (dataAbstractionSet *DataAbstractionSet) ComputeDistancesAfterTransformatio
 		dataAbstractionSet.DistancesAfterTransformation[i] = make([]float64, numberOfDataAbstractionUnits)
 	}
 
-	m := make([]float64, numberOfDataAbstractionUnits)
-
 	for i = 0; i < numberOfDataAbstractionUnits; i++ {
-		originalSpaceDistanceCompare := func(a, b interface{}) int {
-			indexA := a.(*DataAbstractionUnit).DataAbstractionUnitNumber
-			indexB := b.(*DataAbstractionUnit).DataAbstractionUnitNumber
-			if dataAbstractionSet.DistancesBeforeTransformation[i][indexA] < dataAbstractionSet.DistancesBeforeTransformation[i][indexB] {
-				return -1
-			} else if dataAbstractionSet.DistancesBeforeTransformation[i][indexA] == dataAbstractionSet.DistancesBeforeTransformation[i][indexB] {
-				return 0
-			} else {
-				return 1
-			}
+		if ctx.Err() != nil {
+			return ErrorHandling.NewCancellationError("computing distances after transformation", 0, ctx.Err())
 		}
 
-		queue := priorityqueue.NewWith(originalSpaceDistanceCompare)
-
 		for j = 0; j < numberOfDataAbstractionUnits; j++ {
-			if i == j {
-				continue
-			}
-			dataAbstractionUnit2 := &dataAbstractionSet.DataAbstractionUnits[j]
-			queue.Enqueue(dataAbstractionUnit2)
-		}
-
-		for j = 0; j < 20; j++ {
-			dataAbstractionUnit2, _ := queue.Dequeue()
-			if dataAbstractionUnit2 == nil {
-				panic("Not finished successfully.")
-			}
-			neighbourIndex := dataAbstractionUnit2.(*DataAbstractionUnit).DataAbstractionUnitNumber
-			if j == 19 {
-				m[i] = math.Tan(1.0) / dataAbstractionSet.DistancesBeforeTransformation[i][neighbourIndex]
-			}
-		}
-	}
-	for i = 0; i < numberOfDataAbstractionUnits; i++ {
-		for j = 0; j < numberOfDataAbstractionUnits; j++ {
-			distance := dataAbstractionSet.DistancesBeforeTransformation[i][j]
-			dataAbstractionSet.DistancesAfterTransformation[i][j] = (math.Atan(m[i]*distance) + math.Atan(m[j]*distance)) / 2.0
+			dataAbstractionSet.DistancesAfterTransformation[i][j] = TransformDistance(m, i, j, distanceBeforeTransformation(i, j))
 		}
 	}
+
+	return nil
 }
 
 func (dataAbstractionUnit *DataAbstractionUnit) Copy() *DataAbstractionUnit {
@@ -305,6 +313,8 @@ func (dataAbstractionUnit *DataAbstractionUnit) Copy() *DataAbstractionUnit {
 	dataAbstractionUnitCopy.OriginalSpaceCoordinates = make([]float64, len(dataAbstractionUnit.OriginalSpaceCoordinates))
 	copy(dataAbstractionUnitCopy.OriginalSpaceCoordinates, dataAbstractionUnit.OriginalSpaceCoordinates)
 
+	dataAbstractionUnitCopy.ThirtyDimensionalSpaceCoordinates = dataAbstractionUnit.ThirtyDimensionalSpaceCoordinates
+
 	dataAbstractionUnitCopy.ClassLabelNumber = dataAbstractionUnit.ClassLabelNumber
 
 	dataAbstractionUnitCopy.VisualSpaceCoordinates = make([][2]float64, len(dataAbstractionUnit.VisualSpaceCoordinates))
@@ -323,12 +333,18 @@ func (dataAbstractionUnit *DataAbstractionUnit) Copy() *DataAbstractionUnit {
 
 	dataAbstractionUnitCopy.ImageGrayscale = dataAbstractionUnit.ImageGrayscale
 
+	dataAbstractionUnitCopy.ImageWidth = dataAbstractionUnit.ImageWidth
+
+	dataAbstractionUnitCopy.ImageHeight = dataAbstractionUnit.ImageHeight
+
 	dataAbstractionUnitCopy.DataAbstractionUnitNumber = dataAbstractionUnit.DataAbstractionUnitNumber
 
 	dataAbstractionUnitCopy.AreAllVisualSpaceProjectionsIneffective = dataAbstractionUnit.AreAllVisualSpaceProjectionsIneffective
 
 	dataAbstractionUnitCopy.AreAllVisualSpaceProjectionsInRedLayer = dataAbstractionUnit.AreAllVisualSpaceProjectionsInRedLayer
 
+	dataAbstractionUnitCopy.AreAllVisualSpaceProjectionsFrozen = dataAbstractionUnit.AreAllVisualSpaceProjectionsFrozen
+
 	dataAbstractionUnitCopy.Mass = make([]float64, len(dataAbstractionUnit.Mass))
 	copy(dataAbstractionUnitCopy.Mass, dataAbstractionUnit.Mass)
 
@@ -341,6 +357,52 @@ func (dataAbstractionUnit *DataAbstractionUnit) Copy() *DataAbstractionUnit {
 	return dataAbstractionUnitCopy
 }
 
+func (dataAbstractionSet *DataAbstractionSet) Subsample(numberOfSelectedDataAbstractionUnits int, randomSeed int64) []int32 {
+	numberOfDataAbstractionUnits := len(dataAbstractionSet.DataAbstractionUnits)
+	order := rand.New(rand.NewSource(randomSeed)).Perm(numberOfDataAbstractionUnits)
+	sort.Ints(order[:numberOfSelectedDataAbstractionUnits])
+	sort.Ints(order[numberOfSelectedDataAbstractionUnits:])
+
+	originalDataAbstractionUnitNumbers := make([]int32, numberOfDataAbstractionUnits)
+	dataAbstractionUnits := make([]DataAbstractionUnit, numberOfDataAbstractionUnits)
+	for i, originalIndex := range order {
+		dataAbstractionUnits[i] = dataAbstractionSet.DataAbstractionUnits[originalIndex]
+		originalDataAbstractionUnitNumbers[i] = dataAbstractionUnits[i].DataAbstractionUnitNumber
+		dataAbstractionUnits[i].DataAbstractionUnitNumber = int32(i)
+	}
+	dataAbstractionSet.DataAbstractionUnits = dataAbstractionUnits
+
+	if dataAbstractionSet.DistancesBeforeTransformation != nil {
+		distances := make([][]float64, numberOfDataAbstractionUnits)
+		for i, originalIndex := range order {
+			distances[i] = make([]float64, numberOfDataAbstractionUnits)
+			for j, otherOriginalIndex := range order {
+				distances[i][j] = dataAbstractionSet.DistancesBeforeTransformation[originalIndex][otherOriginalIndex]
+			}
+		}
+		dataAbstractionSet.DistancesBeforeTransformation = distances
+	}
+
+	return originalDataAbstractionUnitNumbers[:numberOfSelectedDataAbstractionUnits]
+}
+
+func (dataAbstractionSet *DataAbstractionSet) Copy() DataAbstractionSet {
+	var dataAbstractionSetCopy DataAbstractionSet
+
+	dataAbstractionSetCopy.DataAbstractionUnits = make([]DataAbstractionUnit, len(dataAbstractionSet.DataAbstractionUnits))
+	for i := range dataAbstractionSet.DataAbstractionUnits {
+		dataAbstractionSetCopy.DataAbstractionUnits[i] = *dataAbstractionSet.DataAbstractionUnits[i].Copy()
+	}
+
+	dataAbstractionSetCopy.DistancesBeforeTransformation = dataAbstractionSet.DistancesBeforeTransformation
+	dataAbstractionSetCopy.DistancesAfterTransformation = dataAbstractionSet.DistancesAfterTransformation
+	dataAbstractionSetCopy.DistancesBeforeThirtyDimensionalUMAP = dataAbstractionSet.DistancesBeforeThirtyDimensionalUMAP
+	dataAbstractionSetCopy.DistancesBeforeTransformationSource = dataAbstractionSet.DistancesBeforeTransformationSource
+	dataAbstractionSetCopy.SparseDistancesAfterTransformation = dataAbstractionSet.SparseDistancesAfterTransformation
+
+	return dataAbstractionSetCopy
+}
+
 func (dataAbstractionUnit *DataAbstractionUnit) ToDataAbstractionUnitVisibility(iteration int32, method string) *DataAbstractionUnitVisibility {
 	dataAbstractionUnitVisibility := new(DataAbstractionUnitVisibility)
 
@@ -546,3 +608,138 @@ func EmbeddedDataFromCompareEmbedding(compareEmbeddingMethodName string, compare
 	embeddedData.ExtraClassesLabels = [][]string{}
 	return embeddedData
 }
+
+func (embeddedData *EmbeddedData) ToEmbeddingDetails() (*EmbeddingDetails, error) {
+	embeddingDetails := new(EmbeddingDetails)
+
+	numberOfIterations := 0
+	var maximumZeroBasedIndex int32 = -1
+	for i := 0; i < len(embeddedData.DataInstances); i++ {
+		dataInstance := &embeddedData.DataInstances[i]
+		if len(dataInstance.IterationProjections) == 0 {
+			return nil, fmt.Errorf("%w: data instance %d has no iteration projections", ErrorHandling.ErrMalformedFile, i)
+		}
+		if dataInstance.ZeroBasedIndex < 0 {
+			return nil, fmt.Errorf("%w: data instance %d has the negative zero based index %d", ErrorHandling.ErrMalformedFile, i, dataInstance.ZeroBasedIndex)
+		}
+		if len(dataInstance.IterationProjections) > numberOfIterations {
+			numberOfIterations = len(dataInstance.IterationProjections)
+		}
+		if dataInstance.ZeroBasedIndex > maximumZeroBasedIndex {
+			maximumZeroBasedIndex = dataInstance.ZeroBasedIndex
+		}
+	}
+
+	embeddingDetails.EmbeddingIterations = make([][]*DataAbstractionUnitVisibility, numberOfIterations)
+	for i := 0; i < numberOfIterations; i++ {
+		embeddingDetails.EmbeddingIterations[i] = make([]*DataAbstractionUnitVisibility, len(embeddedData.DataInstances))
+		for j := 0; j < len(embeddedData.DataInstances); j++ {
+			dataInstance := &embeddedData.DataInstances[j]
+			dataAbstractionUnitVisibility := new(DataAbstractionUnitVisibility)
+			dataAbstractionUnitVisibility.ClassLabelNumber = dataInstance.SingleClassNumber
+			dataAbstractionUnitVisibility.DataAbstractionUnitNumber = dataInstance.ZeroBasedIndex
+			dataAbstractionUnitVisibility.Iteration = int32(i + 1)
+			dataAbstractionUnitVisibility.Layer = "NA"
+
+			iterationProjections := dataInstance.IterationProjections[len(dataInstance.IterationProjections)-1]
+			if i < len(dataInstance.IterationProjections) {
+				iterationProjections = dataInstance.IterationProjections[i]
+			}
+
+			dataAbstractionUnitVisibility.VisualSpaceCoordinates = make([][2]float64, len(iterationProjections))
+			for k := 0; k < len(iterationProjections); k++ {
+				dataAbstractionUnitVisibility.VisualSpaceCoordinates[k][0] = iterationProjections[k].X
+				dataAbstractionUnitVisibility.VisualSpaceCoordinates[k][1] = iterationProjections[k].Y
+				if embeddedData.IsRedGray && embeddedData.RedLayerNumber != nil && iterationProjections[k].Layer == *embeddedData.RedLayerNumber {
+					dataAbstractionUnitVisibility.Layer = "red"
+				} else if embeddedData.IsRedGray && embeddedData.GrayLayerNumber != nil && iterationProjections[k].Layer == *embeddedData.GrayLayerNumber {
+					dataAbstractionUnitVisibility.Layer = "gray"
+				}
+			}
+
+			embeddingDetails.EmbeddingIterations[i][j] = dataAbstractionUnitVisibility
+		}
+	}
+
+	embeddingDetails.ClassLabels = embeddedData.SingleClassLabels
+
+	for i := 0; i < len(embeddedData.DataInstances); i++ {
+		dataInstance := &embeddedData.DataInstances[i]
+		for j := 0; j < len(dataInstance.BinaryInfoTypes) && j < len(dataInstance.BinaryInfo); j++ {
+			if dataInstance.BinaryInfoTypes[j] == "ImageRGB" {
+				if embeddingDetails.ImagesRedGreenBlueChannels == nil {
+					embeddingDetails.ImagesRedGreenBlueChannels = make([][]uint8, maximumZeroBasedIndex+1)
+				}
+				embeddingDetails.ImagesRedGreenBlueChannels[dataInstance.ZeroBasedIndex] = dataInstance.BinaryInfo[j]
+			} else if dataInstance.BinaryInfoTypes[j] == "ImageGrayscale" {
+				if embeddingDetails.ImagesGrayscaleSingleChannel == nil {
+					embeddingDetails.ImagesGrayscaleSingleChannel = make([][]uint8, maximumZeroBasedIndex+1)
+				}
+				embeddingDetails.ImagesGrayscaleSingleChannel[dataInstance.ZeroBasedIndex] = dataInstance.BinaryInfo[j]
+			}
+		}
+	}
+
+	if embeddedData.EmbeddingMethodName == "LVSDE" {
+		embeddingMethodParameters := strings.Split(embeddedData.EmbeddingMethodParameters, ",")
+		if len(embeddingMethodParameters) == 3 {
+			embeddingDetails.VisualDensityAdjustmentParameter = embeddingMethodParameters[0]
+			embeddingDetails.NumberOfNeighboursForBuildingNeighbourhoodGraph = embeddingMethodParameters[1]
+			embeddingDetails.PreliminaryToThirtyDimensionsUMAP = embeddingMethodParameters[2]
+		}
+	}
+
+	return embeddingDetails, nil
+}
+
+func (embeddingDetails *EmbeddingDetails) ToDataAbstractionSet() (DataAbstractionSet, error) {
+	var dataAbstractionSet DataAbstractionSet
+
+	if len(embeddingDetails.EmbeddingIterations) == 0 {
+		return dataAbstractionSet, fmt.Errorf("%w: the embedding has no iterations", ErrorHandling.ErrMalformedFile)
+	}
+
+	var maximumDataAbstractionUnitNumber int32 = -1
+	for _, dataAbstractionUnitVisibility := range embeddingDetails.EmbeddingIterations[0] {
+		if dataAbstractionUnitVisibility.DataAbstractionUnitNumber < 0 {
+			return dataAbstractionSet, fmt.Errorf("%w: negative data abstraction unit number %d", ErrorHandling.ErrMalformedFile, dataAbstractionUnitVisibility.DataAbstractionUnitNumber)
+		}
+		if dataAbstractionUnitVisibility.DataAbstractionUnitNumber > maximumDataAbstractionUnitNumber {
+			maximumDataAbstractionUnitNumber = dataAbstractionUnitVisibility.DataAbstractionUnitNumber
+		}
+	}
+
+	dataAbstractionSet.SetDefaultValues(maximumDataAbstractionUnitNumber + 1)
+
+	for _, dataAbstractionUnitVisibility := range embeddingDetails.EmbeddingIterations[0] {
+		i := dataAbstractionUnitVisibility.DataAbstractionUnitNumber
+		dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[i]
+		dataAbstractionUnit.SetDefaultValues()
+		dataAbstractionUnit.DataAbstractionUnitNumber = dataAbstractionUnitVisibility.DataAbstractionUnitNumber
+		dataAbstractionUnit.ClassLabelNumber = dataAbstractionUnitVisibility.ClassLabelNumber
+
+		if embeddingDetails.ImagesGrayscaleSingleChannel != nil && int(i) < len(embeddingDetails.ImagesGrayscaleSingleChannel) {
+			dataAbstractionUnit.ImageGrayscale = embeddingDetails.ImagesGrayscaleSingleChannel[i]
+			dataAbstractionUnit.ImageWidth = embeddingDetails.ImageWidth
+			if dataAbstractionUnit.ImageWidth <= 0 {
+				dataAbstractionUnit.ImageWidth = int32(math.Floor(math.Sqrt(float64(len(dataAbstractionUnit.ImageGrayscale))) + (1e-6)))
+			}
+			if dataAbstractionUnit.ImageWidth > 0 {
+				dataAbstractionUnit.ImageHeight = int32(len(dataAbstractionUnit.ImageGrayscale)) / dataAbstractionUnit.ImageWidth
+			}
+		}
+
+		if embeddingDetails.ImagesRedGreenBlueChannels != nil && int(i) < len(embeddingDetails.ImagesRedGreenBlueChannels) {
+			dataAbstractionUnit.ImageRGB = embeddingDetails.ImagesRedGreenBlueChannels[i]
+			dataAbstractionUnit.ImageWidth = embeddingDetails.ImageWidth
+			if dataAbstractionUnit.ImageWidth <= 0 {
+				dataAbstractionUnit.ImageWidth = int32(math.Floor(math.Sqrt(float64(len(dataAbstractionUnit.ImageRGB))/3.0) + (1e-6)))
+			}
+			if dataAbstractionUnit.ImageWidth > 0 {
+				dataAbstractionUnit.ImageHeight = int32(len(dataAbstractionUnit.ImageRGB)) / (dataAbstractionUnit.ImageWidth * 3)
+			}
+		}
+	}
+
+	return dataAbstractionSet, nil
+}
@@ -0,0 +1,441 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataAbstraction
+
+import (
+	"context"
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const (
+	DistanceSourceOriginalSpaceEuclidean          = "original_space_euclidean"
+	DistanceSourceOriginalSpaceCosine             = "original_space_cosine"
+	DistanceSourceThirtyDimensionalSpaceEuclidean = "thirty_dimensional_space_euclidean"
+)
+
+const NumberOfNeighboursForDistanceTransformation int32 = 20
+
+type Neighbour struct {
+	DataAbstractionUnitIndex int32
+	Distance                 float64
+	isNew                    bool
+}
+
+// NearestNeighbourSearch finds, for every data abstraction unit, the given number of other data abstraction units
+// closest to it before transformation, ordered from the closest one.
+type NearestNeighbourSearch interface {
+	FindNearestNeighbours(ctx context.Context, dataAbstractionSet *DataAbstractionSet, numberOfNeighbours int32) ([][]Neighbour, error)
+}
+
+type ExactNearestNeighbourSearch struct {
+	NumberOfParallelSlices int32
+}
+
+// NNDescentNearestNeighbourSearch approximates the nearest neighbours by repeatedly comparing the neighbours of
+// neighbours, starting from random ones, as in NN-descent by Dong, Charikar and Li. Half of the new neighbours are
+// sampled in each iteration.
+type NNDescentNearestNeighbourSearch struct {
+	NumberOfParallelSlices    int32
+	MaximumNumberOfIterations int32
+	TerminationFraction       float64
+	RandomSeed                int64
+}
+
+type nnDescentUpdate struct {
+	dataAbstractionUnitIndex1 int32
+	dataAbstractionUnitIndex2 int32
+	distance                  float64
+}
+
+type neighbourHeap []Neighbour
+
+func DefaultNumberOfParallelSlices() int32 {
+	numberOfParallelSlices := int32(runtime.NumCPU()) - 1
+	if numberOfParallelSlices < 1 {
+		numberOfParallelSlices = 1
+	}
+	return numberOfParallelSlices
+}
+
+func (exactNearestNeighbourSearch ExactNearestNeighbourSearch) FindNearestNeighbours(ctx context.Context, dataAbstractionSet *DataAbstractionSet, numberOfNeighbours int32) ([][]Neighbour, error) {
+	distance := dataAbstractionSet.DistanceBeforeTransformationFunction()
+	if distance == nil {
+		return nil, fmt.Errorf("%w: neither coordinates nor distances before transformation are available for the exact nearest neighbour search", ErrorHandling.ErrInvalidInput)
+	}
+	return FindExactNearestNeighbours(ctx, int32(len(dataAbstractionSet.DataAbstractionUnits)), distance, numberOfNeighbours, exactNearestNeighbourSearch.NumberOfParallelSlices)
+}
+
+func FindExactNearestNeighbours(ctx context.Context, numberOfDataAbstractionUnits int32, distance func(i int32, j int32) float64, numberOfNeighbours int32, numberOfParallelSlices int32) ([][]Neighbour, error) {
+	if numberOfNeighbours > numberOfDataAbstractionUnits-1 {
+		return nil, notEnoughNeighboursError(numberOfNeighbours, numberOfDataAbstractionUnits)
+	}
+	if numberOfParallelSlices < 1 {
+		numberOfParallelSlices = DefaultNumberOfParallelSlices()
+	}
+
+	nearestNeighbours := make([][]Neighbour, numberOfDataAbstractionUnits)
+	runParallelSlices(ctx, numberOfParallelSlices, numberOfDataAbstractionUnits, func(i int32) {
+		neighbours := make(neighbourHeap, 0, numberOfNeighbours)
+		for j := int32(0); j < numberOfDataAbstractionUnits; j++ {
+			if i == j {
+				continue
+			}
+			neighbours.pushBounded(Neighbour{DataAbstractionUnitIndex: j, Distance: distance(i, j)}, numberOfNeighbours)
+		}
+		nearestNeighbours[i] = neighbours.sorted()
+	})
+
+	if ctx.Err() != nil {
+		return nil, ErrorHandling.NewCancellationError("finding nearest neighbours", 0, ctx.Err())
+	}
+
+	return nearestNeighbours, nil
+}
+
+func (nnDescentNearestNeighbourSearch NNDescentNearestNeighbourSearch) FindNearestNeighbours(ctx context.Context, dataAbstractionSet *DataAbstractionSet, numberOfNeighbours int32) ([][]Neighbour, error) {
+	numberOfDataAbstractionUnits := int32(len(dataAbstractionSet.DataAbstractionUnits))
+	if numberOfNeighbours > numberOfDataAbstractionUnits-1 {
+		return nil, notEnoughNeighboursError(numberOfNeighbours, numberOfDataAbstractionUnits)
+	}
+
+	distance := dataAbstractionSet.coordinateDistanceFunction()
+	if distance == nil {
+		distance = dataAbstractionSet.DistanceBeforeTransformationFunction()
+	}
+	if distance == nil {
+		return nil, fmt.Errorf("%w: neither coordinates nor distances before transformation are available for the nearest neighbour search", ErrorHandling.ErrInvalidInput)
+	}
+
+	numberOfParallelSlices := nnDescentNearestNeighbourSearch.NumberOfParallelSlices
+	if numberOfParallelSlices < 1 {
+		numberOfParallelSlices = DefaultNumberOfParallelSlices()
+	}
+	randomGenerator := rand.New(rand.NewSource(nnDescentNearestNeighbourSearch.RandomSeed))
+	numberOfSampledCandidates := (numberOfNeighbours + 1) / 2
+
+	neighbours := make([]neighbourHeap, numberOfDataAbstractionUnits)
+	for i := int32(0); i < numberOfDataAbstractionUnits; i++ {
+		neighbours[i] = make(neighbourHeap, 0, numberOfNeighbours)
+		for int32(len(neighbours[i])) < numberOfNeighbours {
+			j := randomGenerator.Int31n(numberOfDataAbstractionUnits)
+			if j != i && !neighbours[i].contains(j) {
+				neighbours[i] = append(neighbours[i], Neighbour{DataAbstractionUnitIndex: j, isNew: true})
+			}
+		}
+	}
+
+	runParallelSlices(ctx, numberOfParallelSlices, numberOfDataAbstractionUnits, func(i int32) {
+		for k := range neighbours[i] {
+			neighbours[i][k].Distance = distance(i, neighbours[i][k].DataAbstractionUnitIndex)
+		}
+		neighbours[i].heapify()
+	})
+
+	for iteration := int32(0); iteration < nnDescentNearestNeighbourSearch.MaximumNumberOfIterations; iteration++ {
+		if ctx.Err() != nil {
+			break
+		}
+
+		newCandidates := make([][]int32, numberOfDataAbstractionUnits)
+		oldCandidates := make([][]int32, numberOfDataAbstractionUnits)
+		for i := range neighbours {
+			for k := range neighbours[i] {
+				if neighbours[i][k].isNew {
+					newCandidates[i] = append(newCandidates[i], neighbours[i][k].DataAbstractionUnitIndex)
+				} else {
+					oldCandidates[i] = append(oldCandidates[i], neighbours[i][k].DataAbstractionUnitIndex)
+				}
+			}
+
+			newCandidates[i] = sampleDataAbstractionUnitIndices(newCandidates[i], numberOfSampledCandidates, randomGenerator)
+			for k := range neighbours[i] {
+				if neighbours[i][k].isNew && containsDataAbstractionUnitIndex(newCandidates[i], neighbours[i][k].DataAbstractionUnitIndex) {
+					neighbours[i][k].isNew = false
+				}
+			}
+		}
+
+		reverseNewCandidates := make([][]int32, numberOfDataAbstractionUnits)
+		reverseOldCandidates := make([][]int32, numberOfDataAbstractionUnits)
+		for i := int32(0); i < numberOfDataAbstractionUnits; i++ {
+			for _, j := range newCandidates[i] {
+				reverseNewCandidates[j] = append(reverseNewCandidates[j], i)
+			}
+			for _, j := range oldCandidates[i] {
+				reverseOldCandidates[j] = append(reverseOldCandidates[j], i)
+			}
+		}
+
+		for i := int32(0); i < numberOfDataAbstractionUnits; i++ {
+			newCandidates[i] = uniqueDataAbstractionUnitIndices(append(newCandidates[i], sampleDataAbstractionUnitIndices(reverseNewCandidates[i], numberOfSampledCandidates, randomGenerator)...))
+			oldCandidates[i] = uniqueDataAbstractionUnitIndices(append(oldCandidates[i], sampleDataAbstractionUnitIndices(reverseOldCandidates[i], numberOfSampledCandidates, randomGenerator)...))
+		}
+
+		updatesOfSlices := make([][]nnDescentUpdate, numberOfParallelSlices)
+		runParallelSlices(ctx, numberOfParallelSlices, numberOfDataAbstractionUnits, func(i int32) {
+			sliceNumber := i % numberOfParallelSlices
+			considerPair := func(j1 int32, j2 int32) {
+				pairDistance := distance(j1, j2)
+				if neighbours[j1].isCloserThanFarthest(pairDistance) || neighbours[j2].isCloserThanFarthest(pairDistance) {
+					updatesOfSlices[sliceNumber] = append(updatesOfSlices[sliceNumber], nnDescentUpdate{j1, j2, pairDistance})
+				}
+			}
+
+			for k, j1 := range newCandidates[i] {
+				for _, j2 := range newCandidates[i][k+1:] {
+					considerPair(j1, j2)
+				}
+				for _, j2 := range oldCandidates[i] {
+					if j1 != j2 {
+						considerPair(j1, j2)
+					}
+				}
+			}
+		})
+
+		var numberOfUpdates int64 = 0
+		for _, updates := range updatesOfSlices {
+			for _, update := range updates {
+				if neighbours[update.dataAbstractionUnitIndex1].pushBoundedIfAbsent(Neighbour{DataAbstractionUnitIndex: update.dataAbstractionUnitIndex2, Distance: update.distance, isNew: true}, numberOfNeighbours) {
+					numberOfUpdates++
+				}
+				if neighbours[update.dataAbstractionUnitIndex2].pushBoundedIfAbsent(Neighbour{DataAbstractionUnitIndex: update.dataAbstractionUnitIndex1, Distance: update.distance, isNew: true}, numberOfNeighbours) {
+					numberOfUpdates++
+				}
+			}
+		}
+
+		if float64(numberOfUpdates) <= nnDescentNearestNeighbourSearch.TerminationFraction*float64(numberOfDataAbstractionUnits)*float64(numberOfNeighbours) {
+			break
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil, ErrorHandling.NewCancellationError("finding nearest neighbours", 0, ctx.Err())
+	}
+
+	nearestNeighbours := make([][]Neighbour, numberOfDataAbstractionUnits)
+	for i := range neighbours {
+		nearestNeighbours[i] = neighbours[i].sorted()
+	}
+
+	return nearestNeighbours, nil
+}
+
+func (dataAbstractionSet *DataAbstractionSet) DistanceBeforeTransformationFunction() func(i int32, j int32) float64 {
+	if dataAbstractionSet.DistancesBeforeTransformation != nil {
+		distancesBeforeTransformation := dataAbstractionSet.DistancesBeforeTransformation
+		return func(i int32, j int32) float64 {
+			return distancesBeforeTransformation[i][j]
+		}
+	}
+
+	return dataAbstractionSet.coordinateDistanceFunction()
+}
+
+func (dataAbstractionSet *DataAbstractionSet) coordinateDistanceFunction() func(i int32, j int32) float64 {
+	dataAbstractionUnits := dataAbstractionSet.DataAbstractionUnits
+
+	switch dataAbstractionSet.DistancesBeforeTransformationSource {
+	case DistanceSourceOriginalSpaceEuclidean:
+		return func(i int32, j int32) float64 {
+			return EuclideanDistance(dataAbstractionUnits[i].OriginalSpaceCoordinates, dataAbstractionUnits[j].OriginalSpaceCoordinates)
+		}
+	case DistanceSourceOriginalSpaceCosine:
+		return func(i int32, j int32) float64 {
+			return CosineDistance(dataAbstractionUnits[i].OriginalSpaceCoordinates, dataAbstractionUnits[j].OriginalSpaceCoordinates)
+		}
+	case DistanceSourceThirtyDimensionalSpaceEuclidean:
+		return func(i int32, j int32) float64 {
+			return EuclideanDistance(dataAbstractionUnits[i].ThirtyDimensionalSpaceCoordinates, dataAbstractionUnits[j].ThirtyDimensionalSpaceCoordinates)
+		}
+	}
+
+	return nil
+}
+
+func EuclideanDistance(coordinates1 []float64, coordinates2 []float64) float64 {
+	var distance float64 = 0
+	for k := 0; k < len(coordinates1); k++ {
+		distance += math.Pow(coordinates1[k]-coordinates2[k], 2)
+	}
+	return math.Sqrt(distance)
+}
+
+func CosineDistance(coordinates1 []float64, coordinates2 []float64) float64 {
+	var t1, t2, t3 float64 = 0, 0, 0
+	for k := 0; k < len(coordinates1); k++ {
+		t1 += coordinates1[k] * coordinates2[k]
+		t2 += math.Pow(coordinates1[k], 2)
+		t3 += math.Pow(coordinates2[k], 2)
+	}
+
+	var distance float64 = 1.0 - t1/(math.Sqrt(t2)*math.Sqrt(t3))
+	if math.Abs(t1) < 1e-6 {
+		distance = 1.0
+	}
+	return distance
+}
+
+func runParallelSlices(ctx context.Context, numberOfParallelSlices int32, numberOfDataAbstractionUnits int32, calculate func(i int32)) {
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(int(numberOfParallelSlices))
+	for sliceNumber := int32(0); sliceNumber < numberOfParallelSlices; sliceNumber++ {
+		go func(sliceNumber int32) {
+			defer waitGroup.Done()
+			for i := sliceNumber; i < numberOfDataAbstractionUnits; i += numberOfParallelSlices {
+				if ctx.Err() != nil {
+					return
+				}
+				calculate(i)
+			}
+		}(sliceNumber)
+	}
+	waitGroup.Wait()
+}
+
+func notEnoughNeighboursError(numberOfNeighbours int32, numberOfDataAbstractionUnits int32) error {
+	return fmt.Errorf("%w: %d nearest neighbours are needed but there are only %d other data abstraction units", ErrorHandling.ErrNotEnoughDataAbstractionUnits, numberOfNeighbours, numberOfDataAbstractionUnits-1)
+}
+
+func sampleDataAbstractionUnitIndices(dataAbstractionUnitIndices []int32, maximumNumberOfSamples int32, randomGenerator *rand.Rand) []int32 {
+	if int32(len(dataAbstractionUnitIndices)) <= maximumNumberOfSamples {
+		return dataAbstractionUnitIndices
+	}
+
+	for k := int32(0); k < maximumNumberOfSamples; k++ {
+		l := k + randomGenerator.Int31n(int32(len(dataAbstractionUnitIndices))-k)
+		dataAbstractionUnitIndices[k], dataAbstractionUnitIndices[l] = dataAbstractionUnitIndices[l], dataAbstractionUnitIndices[k]
+	}
+	return dataAbstractionUnitIndices[:maximumNumberOfSamples]
+}
+
+func containsDataAbstractionUnitIndex(dataAbstractionUnitIndices []int32, dataAbstractionUnitIndex int32) bool {
+	for _, otherDataAbstractionUnitIndex := range dataAbstractionUnitIndices {
+		if otherDataAbstractionUnitIndex == dataAbstractionUnitIndex {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueDataAbstractionUnitIndices(dataAbstractionUnitIndices []int32) []int32 {
+	sort.Slice(dataAbstractionUnitIndices, func(a, b int) bool { return dataAbstractionUnitIndices[a] < dataAbstractionUnitIndices[b] })
+	numberOfUniqueIndices := 0
+	for k, dataAbstractionUnitIndex := range dataAbstractionUnitIndices {
+		if k == 0 || dataAbstractionUnitIndex != dataAbstractionUnitIndices[k-1] {
+			dataAbstractionUnitIndices[numberOfUniqueIndices] = dataAbstractionUnitIndex
+			numberOfUniqueIndices++
+		}
+	}
+	return dataAbstractionUnitIndices[:numberOfUniqueIndices]
+}
+
+func isNeighbourCloser(neighbour1 Neighbour, neighbour2 Neighbour) bool {
+	if neighbour1.Distance != neighbour2.Distance {
+		return neighbour1.Distance < neighbour2.Distance
+	}
+	return neighbour1.DataAbstractionUnitIndex < neighbour2.DataAbstractionUnitIndex
+}
+
+// The farthest neighbour is kept at the root so that it can be replaced by a closer one in logarithmic time.
+func (neighbours neighbourHeap) siftDown(k int) {
+	for {
+		farther := 2*k + 1
+		if farther >= len(neighbours) {
+			return
+		}
+		if farther+1 < len(neighbours) && isNeighbourCloser(neighbours[farther], neighbours[farther+1]) {
+			farther++
+		}
+		if !isNeighbourCloser(neighbours[k], neighbours[farther]) {
+			return
+		}
+		neighbours[k], neighbours[farther] = neighbours[farther], neighbours[k]
+		k = farther
+	}
+}
+
+func (neighbours neighbourHeap) heapify() {
+	for k := len(neighbours)/2 - 1; k >= 0; k-- {
+		neighbours.siftDown(k)
+	}
+}
+
+func (neighbours *neighbourHeap) pushBounded(neighbour Neighbour, capacity int32) bool {
+	if int32(len(*neighbours)) < capacity {
+		*neighbours = append(*neighbours, neighbour)
+		heap := *neighbours
+		for k := len(heap) - 1; k > 0; {
+			parent := (k - 1) / 2
+			if !isNeighbourCloser(heap[parent], heap[k]) {
+				break
+			}
+			heap[parent], heap[k] = heap[k], heap[parent]
+			k = parent
+		}
+		return true
+	}
+
+	if capacity == 0 || !isNeighbourCloser(neighbour, (*neighbours)[0]) {
+		return false
+	}
+
+	(*neighbours)[0] = neighbour
+	neighbours.siftDown(0)
+	return true
+}
+
+func (neighbours *neighbourHeap) pushBoundedIfAbsent(neighbour Neighbour, capacity int32) bool {
+	if int32(len(*neighbours)) >= capacity && !neighbours.isCloserThanFarthest(neighbour.Distance) {
+		return false
+	}
+	if neighbours.contains(neighbour.DataAbstractionUnitIndex) {
+		return false
+	}
+	return neighbours.pushBounded(neighbour, capacity)
+}
+
+func (neighbours neighbourHeap) isCloserThanFarthest(distance float64) bool {
+	return len(neighbours) == 0 || distance < neighbours[0].Distance
+}
+
+func (neighbours neighbourHeap) contains(dataAbstractionUnitIndex int32) bool {
+	for _, neighbour := range neighbours {
+		if neighbour.DataAbstractionUnitIndex == dataAbstractionUnitIndex {
+			return true
+		}
+	}
+	return false
+}
+
+func (neighbours neighbourHeap) sorted() []Neighbour {
+	sortedNeighbours := make([]Neighbour, len(neighbours))
+	copy(sortedNeighbours, neighbours)
+	sort.Slice(sortedNeighbours, func(a, b int) bool { return isNeighbourCloser(sortedNeighbours[a], sortedNeighbours[b]) })
+	for k := range sortedNeighbours {
+		sortedNeighbours[k].isNew = false
+	}
+	return sortedNeighbours
+}
@@ -0,0 +1,133 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataAbstraction
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// SparseDistancesAfterTransformation keeps the distances after transformation only from every data abstraction unit
+// to its neighbours, so that its memory grows linearly with the number of data abstraction units. Any other distance
+// is computed from the coordinates when it is needed.
+type SparseDistancesAfterTransformation struct {
+	Neighbours                   [][]Neighbour
+	TransformationFactors        []float64
+	distanceBeforeTransformation func(i int32, j int32) float64
+}
+
+func (dataAbstractionSet *DataAbstractionSet) DistanceAfterTransformation(i int32, j int32) float64 {
+	if dataAbstractionSet.DistancesAfterTransformation != nil {
+		return dataAbstractionSet.DistancesAfterTransformation[i][j]
+	}
+	return dataAbstractionSet.SparseDistancesAfterTransformation.Distance(i, j)
+}
+
+func (dataAbstractionSet *DataAbstractionSet) HasDistancesAfterTransformation() bool {
+	return dataAbstractionSet.DistancesAfterTransformation != nil || dataAbstractionSet.SparseDistancesAfterTransformation != nil
+}
+
+func (dataAbstractionSet *DataAbstractionSet) ComputeSparseDistancesAfterTransformationFromNearestNeighbours(nearestNeighbours [][]Neighbour) error {
+	transformationFactors, err := dataAbstractionSet.ComputeTransformationFactors(nearestNeighbours)
+	if err != nil {
+		return err
+	}
+
+	distanceBeforeTransformation := dataAbstractionSet.DistanceBeforeTransformationFunction()
+	if distanceBeforeTransformation == nil {
+		return fmt.Errorf("%w: neither coordinates nor distances before transformation are available", ErrorHandling.ErrInvalidInput)
+	}
+
+	dataAbstractionSet.SparseDistancesAfterTransformation = &SparseDistancesAfterTransformation{
+		Neighbours:                   make([][]Neighbour, len(dataAbstractionSet.DataAbstractionUnits)),
+		TransformationFactors:        transformationFactors,
+		distanceBeforeTransformation: distanceBeforeTransformation,
+	}
+
+	return nil
+}
+
+func (dataAbstractionSet *DataAbstractionSet) ComputeTransformationFactors(nearestNeighbours [][]Neighbour) ([]float64, error) {
+	numberOfDataAbstractionUnits := int32(len(dataAbstractionSet.DataAbstractionUnits))
+
+	if numberOfDataAbstractionUnits <= NumberOfNeighboursForDistanceTransformation {
+		return nil, fmt.Errorf("%w: %d data abstraction units found but at least %d are needed", ErrorHandling.ErrNotEnoughDataAbstractionUnits, numberOfDataAbstractionUnits, NumberOfNeighboursForDistanceTransformation+1)
+	}
+
+	transformationFactors := make([]float64, numberOfDataAbstractionUnits)
+	for i := int32(0); i < numberOfDataAbstractionUnits; i++ {
+		if int32(len(nearestNeighbours[i])) < NumberOfNeighboursForDistanceTransformation {
+			return nil, fmt.Errorf("%w: data abstraction unit %d has fewer than %d neighbours", ErrorHandling.ErrNotEnoughDataAbstractionUnits, i, NumberOfNeighboursForDistanceTransformation)
+		}
+		transformationFactors[i] = math.Tan(1.0) / nearestNeighbours[i][NumberOfNeighboursForDistanceTransformation-1].Distance
+	}
+
+	return transformationFactors, nil
+}
+
+func TransformDistance(transformationFactors []float64, i int32, j int32, distance float64) float64 {
+	return (math.Atan(transformationFactors[i]*distance) + math.Atan(transformationFactors[j]*distance)) / 2.0
+}
+
+func (sparseDistancesAfterTransformation *SparseDistancesAfterTransformation) Distance(i int32, j int32) float64 {
+	neighbours := sparseDistancesAfterTransformation.Neighbours[i]
+	k := sort.Search(len(neighbours), func(k int) bool { return neighbours[k].DataAbstractionUnitIndex >= j })
+	if k < len(neighbours) && neighbours[k].DataAbstractionUnitIndex == j {
+		return neighbours[k].Distance
+	}
+	return TransformDistance(sparseDistancesAfterTransformation.TransformationFactors, i, j, sparseDistancesAfterTransformation.distanceBeforeTransformation(i, j))
+}
+
+// SetNeighbours keeps the distances after transformation from a data abstraction unit to the given neighbours,
+// ordered by their indices so that they can be looked up by binary search.
+func (sparseDistancesAfterTransformation *SparseDistancesAfterTransformation) SetNeighbours(i int32, neighbours []Neighbour) {
+	sortedNeighbours := make([]Neighbour, len(neighbours))
+	copy(sortedNeighbours, neighbours)
+	sort.Slice(sortedNeighbours, func(a, b int) bool {
+		return sortedNeighbours[a].DataAbstractionUnitIndex < sortedNeighbours[b].DataAbstractionUnitIndex
+	})
+	sparseDistancesAfterTransformation.Neighbours[i] = sortedNeighbours
+}
+
+// EstimateMaximumDistanceAfterTransformation returns the largest distance after transformation among the kept
+// neighbour distances and the given number of randomly sampled pairs per data abstraction unit.
+func (dataAbstractionSet *DataAbstractionSet) EstimateMaximumDistanceAfterTransformation(numberOfSampledPairsPerDataAbstractionUnit int32, randomSeed int64) float64 {
+	numberOfDataAbstractionUnits := int32(len(dataAbstractionSet.DataAbstractionUnits))
+	randomGenerator := rand.New(rand.NewSource(randomSeed))
+
+	var maximumDistance float64 = 0
+	if dataAbstractionSet.SparseDistancesAfterTransformation != nil {
+		for _, neighbours := range dataAbstractionSet.SparseDistancesAfterTransformation.Neighbours {
+			for _, neighbour := range neighbours {
+				maximumDistance = math.Max(maximumDistance, neighbour.Distance)
+			}
+		}
+	}
+
+	for i := int32(0); i < numberOfDataAbstractionUnits; i++ {
+		for k := int32(0); k < numberOfSampledPairsPerDataAbstractionUnit; k++ {
+			j := randomGenerator.Int31n(numberOfDataAbstractionUnits)
+			maximumDistance = math.Max(maximumDistance, dataAbstractionSet.DistanceAfterTransformation(i, j))
+		}
+	}
+
+	return maximumDistance
+}
@@ -0,0 +1,289 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"math"
+)
+
+const (
+	RepulsiveForceMethodExact     = "exact"
+	RepulsiveForceMethodBarnesHut = "barnes_hut"
+)
+
+var RepulsiveForceMethods = []string{RepulsiveForceMethodExact, RepulsiveForceMethodBarnesHut}
+
+const DefaultOpeningAngle float64 = 0.5
+
+const MaximumQuadtreeDepth int32 = 48
+
+type RepulsiveForceConfiguration struct {
+	Method       string  `json:"method"`
+	OpeningAngle float64 `json:"opening_angle"`
+}
+
+func DefaultRepulsiveForceConfiguration() RepulsiveForceConfiguration {
+	return RepulsiveForceConfiguration{
+		Method:       RepulsiveForceMethodExact,
+		OpeningAngle: DefaultOpeningAngle,
+	}
+}
+
+type QuadtreeProjection struct {
+	VisualSpaceCoordinates     [2]float64
+	DataAbstractionUnitIndex   int32
+	VisualSpaceProjectionIndex int32
+}
+
+type QuadtreeNode struct {
+	CentreX             float64
+	CentreY             float64
+	HalfSize            float64
+	CentreOfMassX       float64
+	CentreOfMassY       float64
+	Mass                float64
+	Children            [4]int32
+	FirstProjection     int32
+	NumberOfProjections int32
+}
+
+type Quadtree struct {
+	Projections []QuadtreeProjection
+	Nodes       []QuadtreeNode
+}
+
+func (quadtreeNode *QuadtreeNode) IsLeaf() bool {
+	return quadtreeNode.Children[0] < 0 && quadtreeNode.Children[1] < 0 && quadtreeNode.Children[2] < 0 && quadtreeNode.Children[3] < 0
+}
+
+func (quadtreeNode *QuadtreeNode) Contains(x float64, y float64) bool {
+	return math.Abs(x-quadtreeNode.CentreX) <= quadtreeNode.HalfSize && math.Abs(y-quadtreeNode.CentreY) <= quadtreeNode.HalfSize
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) BuildQuadtree() {
+	quadtree := dataEmbeddingTechniqueLVSDE.Quadtree
+	if quadtree == nil {
+		quadtree = new(Quadtree)
+		dataEmbeddingTechniqueLVSDE.Quadtree = quadtree
+	}
+	quadtree.Projections = quadtree.Projections[:0]
+	quadtree.Nodes = quadtree.Nodes[:0]
+
+	var xLow float64 = math.Inf(1)
+	var xHigh float64 = math.Inf(-1)
+	var yLow float64 = math.Inf(1)
+	var yHigh float64 = math.Inf(-1)
+
+	dataAbstractionUnits := dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits
+	for i := range dataAbstractionUnits {
+		if dataAbstractionUnits[i].AreAllVisualSpaceProjectionsIneffective {
+			continue
+		}
+		for j, visualSpaceCoordinates := range dataAbstractionUnits[i].VisualSpaceCoordinates {
+			quadtree.Projections = append(quadtree.Projections, QuadtreeProjection{
+				VisualSpaceCoordinates:     visualSpaceCoordinates,
+				DataAbstractionUnitIndex:   int32(i),
+				VisualSpaceProjectionIndex: int32(j),
+			})
+			xLow = math.Min(xLow, visualSpaceCoordinates[0])
+			xHigh = math.Max(xHigh, visualSpaceCoordinates[0])
+			yLow = math.Min(yLow, visualSpaceCoordinates[1])
+			yHigh = math.Max(yHigh, visualSpaceCoordinates[1])
+		}
+	}
+
+	if len(quadtree.Projections) == 0 {
+		return
+	}
+
+	halfSize := math.Max(xHigh-xLow, yHigh-yLow) / 2
+	if halfSize == 0 {
+		halfSize = 1
+	}
+	quadtree.buildNode(0, int32(len(quadtree.Projections)), (xLow+xHigh)/2, (yLow+yHigh)/2, halfSize, 0)
+}
+
+func (quadtree *Quadtree) buildNode(firstProjection int32, numberOfProjections int32, centreX float64, centreY float64, halfSize float64, depth int32) int32 {
+	nodeIndex := int32(len(quadtree.Nodes))
+	quadtree.Nodes = append(quadtree.Nodes, QuadtreeNode{
+		CentreX:             centreX,
+		CentreY:             centreY,
+		HalfSize:            halfSize,
+		Children:            [4]int32{-1, -1, -1, -1},
+		FirstProjection:     firstProjection,
+		NumberOfProjections: numberOfProjections,
+	})
+
+	projections := quadtree.Projections[firstProjection : firstProjection+numberOfProjections]
+	var centreOfMassX, centreOfMassY float64 = 0, 0
+	for _, quadtreeProjection := range projections {
+		centreOfMassX += quadtreeProjection.VisualSpaceCoordinates[0]
+		centreOfMassY += quadtreeProjection.VisualSpaceCoordinates[1]
+	}
+	mass := float64(numberOfProjections)
+	quadtree.Nodes[nodeIndex].CentreOfMassX = centreOfMassX / mass
+	quadtree.Nodes[nodeIndex].CentreOfMassY = centreOfMassY / mass
+	quadtree.Nodes[nodeIndex].Mass = mass
+
+	if numberOfProjections <= 1 || depth >= MaximumQuadtreeDepth {
+		return nodeIndex
+	}
+
+	quadrantOf := func(quadtreeProjection *QuadtreeProjection) int {
+		quadrant := 0
+		if quadtreeProjection.VisualSpaceCoordinates[0] >= centreX {
+			quadrant++
+		}
+		if quadtreeProjection.VisualSpaceCoordinates[1] >= centreY {
+			quadrant += 2
+		}
+		return quadrant
+	}
+
+	var numbersOfProjectionsOfQuadrants [4]int32
+	for i := range projections {
+		numbersOfProjectionsOfQuadrants[quadrantOf(&projections[i])]++
+	}
+
+	var firstProjectionsOfQuadrants [4]int32
+	var nextProjectionsOfQuadrants [4]int32
+	for quadrant := 1; quadrant < 4; quadrant++ {
+		firstProjectionsOfQuadrants[quadrant] = firstProjectionsOfQuadrants[quadrant-1] + numbersOfProjectionsOfQuadrants[quadrant-1]
+	}
+	nextProjectionsOfQuadrants = firstProjectionsOfQuadrants
+	for quadrant := 0; quadrant < 4; quadrant++ {
+		for nextProjectionsOfQuadrants[quadrant] < firstProjectionsOfQuadrants[quadrant]+numbersOfProjectionsOfQuadrants[quadrant] {
+			i := nextProjectionsOfQuadrants[quadrant]
+			targetQuadrant := quadrantOf(&projections[i])
+			if targetQuadrant == quadrant {
+				nextProjectionsOfQuadrants[quadrant]++
+				continue
+			}
+			target := nextProjectionsOfQuadrants[targetQuadrant]
+			projections[i], projections[target] = projections[target], projections[i]
+			nextProjectionsOfQuadrants[targetQuadrant]++
+		}
+	}
+
+	quarterSize := halfSize / 2
+	for quadrant := 0; quadrant < 4; quadrant++ {
+		if numbersOfProjectionsOfQuadrants[quadrant] == 0 {
+			continue
+		}
+		childCentreX := centreX - quarterSize
+		if quadrant&1 != 0 {
+			childCentreX = centreX + quarterSize
+		}
+		childCentreY := centreY - quarterSize
+		if quadrant&2 != 0 {
+			childCentreY = centreY + quarterSize
+		}
+		childIndex := quadtree.buildNode(firstProjection+firstProjectionsOfQuadrants[quadrant], numbersOfProjectionsOfQuadrants[quadrant], childCentreX, childCentreY, quarterSize, depth+1)
+		quadtree.Nodes[nodeIndex].Children[quadrant] = childIndex
+	}
+
+	return nodeIndex
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) CalculateRepulsiveForcesSliceBarnesHut(sliceNumber int32) {
+	defer dataEmbeddingTechniqueLVSDE.WaitGroup.Done()
+
+	quadtree := dataEmbeddingTechniqueLVSDE.Quadtree
+	if len(quadtree.Nodes) == 0 {
+		return
+	}
+
+	openingAngle := dataEmbeddingTechniqueLVSDE.RepulsiveForces.OpeningAngle
+	stack := make([]int32, 0, 4*MaximumQuadtreeDepth)
+
+	var numberOfDataAbstractionUnits int32 = int32(len(dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits))
+	var i, j int32
+	for i = sliceNumber; i < numberOfDataAbstractionUnits; i += dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices {
+		if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+			return
+		}
+
+		dataAbstractionUnit1 := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i]
+		if dataAbstractionUnit1.AreAllVisualSpaceProjectionsIneffective {
+			continue
+		}
+
+		for j = 0; j < int32(len(dataAbstractionUnit1.VisualSpaceCoordinates)); j++ {
+			visualSpaceCoordinates1 := dataAbstractionUnit1.VisualSpaceCoordinates[j]
+
+			stack = append(stack[:0], 0)
+			for len(stack) > 0 {
+				quadtreeNode := &quadtree.Nodes[stack[len(stack)-1]]
+				stack = stack[:len(stack)-1]
+
+				if quadtreeNode.IsLeaf() {
+					for _, quadtreeProjection := range quadtree.Projections[quadtreeNode.FirstProjection : quadtreeNode.FirstProjection+quadtreeNode.NumberOfProjections] {
+						if quadtreeProjection.DataAbstractionUnitIndex == i && quadtreeProjection.VisualSpaceProjectionIndex == j {
+							continue
+						}
+						dataEmbeddingTechniqueLVSDE.AddRepulsiveForce(dataAbstractionUnit1, j, visualSpaceCoordinates1[0]-quadtreeProjection.VisualSpaceCoordinates[0], visualSpaceCoordinates1[1]-quadtreeProjection.VisualSpaceCoordinates[1], 1)
+					}
+					continue
+				}
+
+				horizontalDifference := visualSpaceCoordinates1[0] - quadtreeNode.CentreOfMassX
+				verticalDifference := visualSpaceCoordinates1[1] - quadtreeNode.CentreOfMassY
+				visualDistance := math.Sqrt(math.Pow(horizontalDifference, 2) + math.Pow(verticalDifference, 2))
+
+				if !quadtreeNode.Contains(visualSpaceCoordinates1[0], visualSpaceCoordinates1[1]) && 2*quadtreeNode.HalfSize < openingAngle*visualDistance {
+					dataEmbeddingTechniqueLVSDE.AddRepulsiveForce(dataAbstractionUnit1, j, horizontalDifference, verticalDifference, quadtreeNode.Mass)
+					continue
+				}
+
+				for _, childIndex := range quadtreeNode.Children {
+					if childIndex >= 0 {
+						stack = append(stack, childIndex)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) AddRepulsiveForce(dataAbstractionUnit1 *DataAbstraction.DataAbstractionUnit, j int32, horizontalDifference float64, verticalDifference float64, mass float64) {
+	visualDistance := math.Sqrt(math.Pow(horizontalDifference, 2) + math.Pow(verticalDifference, 2))
+
+	if visualDistance < dataEmbeddingTechniqueLVSDE.Epsilon {
+		visualDistance = dataEmbeddingTechniqueLVSDE.Epsilon
+	}
+
+	repulsiveMagnitude := mass * dataEmbeddingTechniqueLVSDE.SquaredBaseDistance / visualDistance
+	repulsiveVectorX := repulsiveMagnitude * (horizontalDifference / visualDistance)
+	repulsiveVectorY := repulsiveMagnitude * (verticalDifference / visualDistance)
+
+	dataAbstractionUnit1.TemporaryVisualSpaceCoordinates[j][0] += repulsiveVectorX
+	dataAbstractionUnit1.TemporaryVisualSpaceCoordinates[j][1] += repulsiveVectorY
+
+	if dataEmbeddingTechniqueLVSDE.CurrentPhase >= 2 {
+		for axis := 0; axis < 36; axis++ {
+			pressure := dataEmbeddingTechniqueLVSDE.PrecomputedCosineOfAxisAngle[axis] * repulsiveVectorX
+			pressure += dataEmbeddingTechniqueLVSDE.PrecomputedSineOfAxisAngle[axis] * repulsiveVectorY
+			if pressure > 0 {
+				dataAbstractionUnit1.VisualSpacePositiveReplicationPressuresPerAxis[j][axis] += pressure
+			} else {
+				dataAbstractionUnit1.VisualSpaceNegativeReplicationPressuresPerAxis[j][axis] += -pressure
+			}
+		}
+	}
+}
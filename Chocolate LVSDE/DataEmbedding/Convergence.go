@@ -0,0 +1,90 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
+	"math"
+)
+
+const DefaultRelativeMeanDisplacementThreshold float64 = 0.0005
+
+const DefaultNumberOfConvergedIterations int32 = 20
+
+type ConvergenceCriterion struct {
+	RelativeMeanDisplacementThreshold float64 `json:"relative_mean_displacement_threshold"`
+	NumberOfConvergedIterations       int32   `json:"number_of_converged_iterations"`
+}
+
+func DefaultConvergenceCriterion() ConvergenceCriterion {
+	return ConvergenceCriterion{
+		RelativeMeanDisplacementThreshold: DefaultRelativeMeanDisplacementThreshold,
+		NumberOfConvergedIterations:       DefaultNumberOfConvergedIterations,
+	}
+}
+
+func IsPhaseEndedByConvergence(phase int32) bool {
+	return phase == 1 || phase == 3 || phase == 4
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) RelativeMeanDisplacement(meanDisplacement float64) float64 {
+	var xLow float64 = math.Inf(1)
+	var xHigh float64 = math.Inf(-1)
+	var yLow float64 = math.Inf(1)
+	var yHigh float64 = math.Inf(-1)
+
+	for _, dataAbstractionUnit := range dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits {
+		for _, visualSpaceCoordinates := range dataAbstractionUnit.VisualSpaceCoordinates {
+			xLow = math.Min(xLow, visualSpaceCoordinates[0])
+			xHigh = math.Max(xHigh, visualSpaceCoordinates[0])
+			yLow = math.Min(yLow, visualSpaceCoordinates[1])
+			yHigh = math.Max(yHigh, visualSpaceCoordinates[1])
+		}
+	}
+
+	diagonal := math.Hypot(xHigh-xLow, yHigh-yLow)
+	if diagonal == 0 || math.IsInf(diagonal, 0) || math.IsNaN(diagonal) {
+		return math.Inf(1)
+	}
+	return meanDisplacement / diagonal
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) UpdateConvergence(meanDisplacement float64) {
+	convergenceCriterion := dataEmbeddingTechniqueLVSDE.ConvergenceCriterion
+	if convergenceCriterion == nil || !IsPhaseEndedByConvergence(dataEmbeddingTechniqueLVSDE.CurrentPhase) {
+		return
+	}
+
+	relativeMeanDisplacement := dataEmbeddingTechniqueLVSDE.RelativeMeanDisplacement(meanDisplacement)
+	if relativeMeanDisplacement < convergenceCriterion.RelativeMeanDisplacementThreshold {
+		dataEmbeddingTechniqueLVSDE.NumberOfConsecutiveConvergedIterations++
+	} else {
+		dataEmbeddingTechniqueLVSDE.NumberOfConsecutiveConvergedIterations = 0
+	}
+
+	if dataEmbeddingTechniqueLVSDE.NumberOfConsecutiveConvergedIterations >= convergenceCriterion.NumberOfConvergedIterations {
+		dataEmbeddingTechniqueLVSDE.HasPhaseConverged = true
+		Logging.Info("LVSDE phase converged.", Logging.Fields{
+			"iteration":                      dataEmbeddingTechniqueLVSDE.Iteration,
+			"phase":                          dataEmbeddingTechniqueLVSDE.CurrentPhase,
+			"skipped_iterations_of_phase":    dataEmbeddingTechniqueLVSDE.PhaseSchedule.LastIterationOfPhase(dataEmbeddingTechniqueLVSDE.CurrentPhase) - dataEmbeddingTechniqueLVSDE.Iteration - dataEmbeddingTechniqueLVSDE.IterationOffset,
+			"relative_mean_displacement":     relativeMeanDisplacement,
+			"number_of_converged_iterations": dataEmbeddingTechniqueLVSDE.NumberOfConsecutiveConvergedIterations,
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"math"
+	"sort"
+)
+
+// ConvexHull returns the vertices of the convex hull of the points in counterclockwise order, without collinear
+// vertices, using the monotone chain algorithm.
+func ConvexHull(points [][2]float64) [][2]float64 {
+	sortedPoints := append([][2]float64(nil), points...)
+	sort.Slice(sortedPoints, func(a, b int) bool {
+		if sortedPoints[a][0] != sortedPoints[b][0] {
+			return sortedPoints[a][0] < sortedPoints[b][0]
+		}
+		return sortedPoints[a][1] < sortedPoints[b][1]
+	})
+	if len(sortedPoints) < 3 {
+		return sortedPoints
+	}
+
+	convexHull := make([][2]float64, 0, 2*len(sortedPoints))
+	for _, point := range sortedPoints {
+		for len(convexHull) >= 2 && crossProduct(convexHull[len(convexHull)-2], convexHull[len(convexHull)-1], point) <= 0 {
+			convexHull = convexHull[:len(convexHull)-1]
+		}
+		convexHull = append(convexHull, point)
+	}
+	lowerHullLength := len(convexHull)
+	for i := len(sortedPoints) - 2; i >= 0; i-- {
+		point := sortedPoints[i]
+		for len(convexHull) > lowerHullLength && crossProduct(convexHull[len(convexHull)-2], convexHull[len(convexHull)-1], point) <= 0 {
+			convexHull = convexHull[:len(convexHull)-1]
+		}
+		convexHull = append(convexHull, point)
+	}
+
+	return convexHull[:len(convexHull)-1]
+}
+
+// MaximumDistanceBetweenPoints returns the largest distance between two of the points, found with rotating calipers
+// over their convex hull in O(n log n) time rather than by comparing every pair.
+func MaximumDistanceBetweenPoints(points [][2]float64) float64 {
+	convexHull := ConvexHull(points)
+	numberOfVertices := len(convexHull)
+	if numberOfVertices < 2 {
+		return 0
+	}
+
+	maximumSquaredDistance := 0.0
+	var farthestPair [2][2]float64
+	updateMaximum := func(point1 [2]float64, point2 [2]float64) {
+		squaredDistance := math.Pow(point1[0]-point2[0], 2) + math.Pow(point1[1]-point2[1], 2)
+		if squaredDistance > maximumSquaredDistance {
+			maximumSquaredDistance = squaredDistance
+			farthestPair = [2][2]float64{point1, point2}
+		}
+	}
+
+	if numberOfVertices == 2 {
+		updateMaximum(convexHull[0], convexHull[1])
+	} else {
+		k := 1
+		for i := 0; i < numberOfVertices; i++ {
+			next := (i + 1) % numberOfVertices
+			for crossProduct(convexHull[i], convexHull[next], convexHull[(k+1)%numberOfVertices]) > crossProduct(convexHull[i], convexHull[next], convexHull[k]) {
+				k = (k + 1) % numberOfVertices
+			}
+			updateMaximum(convexHull[i], convexHull[k])
+			updateMaximum(convexHull[next], convexHull[k])
+		}
+	}
+
+	horizontalDifference := farthestPair[0][0] - farthestPair[1][0]
+	verticalDifference := farthestPair[0][1] - farthestPair[1][1]
+	return math.Sqrt(math.Pow(horizontalDifference, 2) + math.Pow(verticalDifference, 2))
+}
+
+func crossProduct(origin [2]float64, point1 [2]float64, point2 [2]float64) float64 {
+	return (point1[0]-origin[0])*(point2[1]-origin[1]) - (point1[1]-origin[1])*(point2[0]-origin[0])
+}
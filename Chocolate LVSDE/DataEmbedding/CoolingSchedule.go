@@ -0,0 +1,157 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"math"
+)
+
+const (
+	CoolingScheduleTypeLinear      = "linear"
+	CoolingScheduleTypeExponential = "exponential"
+	CoolingScheduleTypeCosine      = "cosine"
+	CoolingScheduleTypeAdaptive    = "adaptive"
+)
+
+var CoolingScheduleTypes = []string{CoolingScheduleTypeLinear, CoolingScheduleTypeExponential, CoolingScheduleTypeCosine, CoolingScheduleTypeAdaptive}
+
+const DefaultMinimumTemperatureRatio float64 = 0.01
+
+const DefaultShrinkFactor float64 = 0.9
+
+const DefaultPatience int32 = 10
+
+type CoolingScheduleConfiguration struct {
+	Type                    string  `json:"type"`
+	MinimumTemperatureRatio float64 `json:"minimum_temperature_ratio"`
+	ShrinkFactor            float64 `json:"shrink_factor"`
+	Patience                int32   `json:"patience"`
+}
+
+type CoolingState struct {
+	Iteration                           int32
+	Phase                               int32
+	InitialTemperature                  float64
+	PhaseSchedule                       *PhaseSchedule
+	MeanDisplacementOfPreviousIteration float64
+	FirstIterationOfRun                 int32
+}
+
+type CoolingSchedule interface {
+	Temperature(coolingState CoolingState) float64
+}
+
+func DefaultCoolingScheduleConfiguration() CoolingScheduleConfiguration {
+	return CoolingScheduleConfiguration{
+		Type:                    CoolingScheduleTypeLinear,
+		MinimumTemperatureRatio: DefaultMinimumTemperatureRatio,
+		ShrinkFactor:            DefaultShrinkFactor,
+		Patience:                DefaultPatience,
+	}
+}
+
+func NewCoolingSchedule(coolingScheduleConfiguration CoolingScheduleConfiguration) CoolingSchedule {
+	switch coolingScheduleConfiguration.Type {
+	case CoolingScheduleTypeExponential:
+		return ExponentialCoolingSchedule{MinimumTemperatureRatio: coolingScheduleConfiguration.MinimumTemperatureRatio}
+	case CoolingScheduleTypeCosine:
+		return CosineCoolingSchedule{}
+	case CoolingScheduleTypeAdaptive:
+		return &AdaptiveCoolingSchedule{ShrinkFactor: coolingScheduleConfiguration.ShrinkFactor, Patience: coolingScheduleConfiguration.Patience}
+	}
+	return LinearCoolingSchedule{}
+}
+
+func (coolingState *CoolingState) FirstIterationOfPhase() int32 {
+	return coolingState.PhaseSchedule.LastIterationOfPhase(coolingState.Phase-1) + 1
+}
+
+func (coolingState *CoolingState) LastIterationOfPhase() int32 {
+	return coolingState.PhaseSchedule.LastIterationOfPhase(coolingState.Phase)
+}
+
+func (coolingState *CoolingState) ProgressOfPhase() float64 {
+	firstIteration := coolingState.FirstIterationOfPhase()
+	lastIteration := coolingState.LastIterationOfPhase()
+	if lastIteration <= firstIteration {
+		return 0
+	}
+	return float64(coolingState.Iteration-firstIteration) / float64(lastIteration-firstIteration)
+}
+
+func (coolingState *CoolingState) LinearTemperatureAt(iteration int32) float64 {
+	temperatureAdjustment := coolingState.PhaseSchedule.TemperatureAdjustmentOfPhase(coolingState.Phase)
+	temperature := coolingState.InitialTemperature - (float64(iteration-temperatureAdjustment)/coolingState.PhaseSchedule.TemperatureDecayIterations)*coolingState.InitialTemperature
+	return math.Max(0, temperature)
+}
+
+type LinearCoolingSchedule struct {
+}
+
+func (linearCoolingSchedule LinearCoolingSchedule) Temperature(coolingState CoolingState) float64 {
+	return coolingState.LinearTemperatureAt(coolingState.Iteration)
+}
+
+type ExponentialCoolingSchedule struct {
+	MinimumTemperatureRatio float64
+}
+
+func (exponentialCoolingSchedule ExponentialCoolingSchedule) Temperature(coolingState CoolingState) float64 {
+	startTemperature := coolingState.LinearTemperatureAt(coolingState.FirstIterationOfPhase())
+	endTemperature := math.Max(coolingState.LinearTemperatureAt(coolingState.LastIterationOfPhase()), coolingState.InitialTemperature*exponentialCoolingSchedule.MinimumTemperatureRatio)
+	if startTemperature <= endTemperature {
+		return startTemperature
+	}
+	return startTemperature * math.Pow(endTemperature/startTemperature, coolingState.ProgressOfPhase())
+}
+
+type CosineCoolingSchedule struct {
+}
+
+func (cosineCoolingSchedule CosineCoolingSchedule) Temperature(coolingState CoolingState) float64 {
+	startTemperature := coolingState.LinearTemperatureAt(coolingState.FirstIterationOfPhase())
+	endTemperature := coolingState.LinearTemperatureAt(coolingState.LastIterationOfPhase())
+	return endTemperature + (startTemperature-endTemperature)*(1+math.Cos(math.Pi*coolingState.ProgressOfPhase()))/2
+}
+
+type AdaptiveCoolingSchedule struct {
+	ShrinkFactor                         float64
+	Patience                             int32
+	factor                               float64
+	numberOfIterationsWithoutImprovement int32
+	lowestMeanDisplacement               float64
+}
+
+func (adaptiveCoolingSchedule *AdaptiveCoolingSchedule) Temperature(coolingState CoolingState) float64 {
+	if coolingState.Iteration == coolingState.FirstIterationOfPhase() || coolingState.Iteration == coolingState.FirstIterationOfRun || adaptiveCoolingSchedule.factor == 0 {
+		adaptiveCoolingSchedule.factor = 1
+		adaptiveCoolingSchedule.numberOfIterationsWithoutImprovement = 0
+		adaptiveCoolingSchedule.lowestMeanDisplacement = math.Inf(1)
+	} else if coolingState.MeanDisplacementOfPreviousIteration < adaptiveCoolingSchedule.lowestMeanDisplacement {
+		adaptiveCoolingSchedule.lowestMeanDisplacement = coolingState.MeanDisplacementOfPreviousIteration
+		adaptiveCoolingSchedule.numberOfIterationsWithoutImprovement = 0
+	} else {
+		adaptiveCoolingSchedule.numberOfIterationsWithoutImprovement++
+		if adaptiveCoolingSchedule.numberOfIterationsWithoutImprovement >= adaptiveCoolingSchedule.Patience {
+			adaptiveCoolingSchedule.factor *= adaptiveCoolingSchedule.ShrinkFactor
+			adaptiveCoolingSchedule.numberOfIterationsWithoutImprovement = 0
+		}
+	}
+
+	return coolingState.LinearTemperatureAt(coolingState.Iteration) * adaptiveCoolingSchedule.factor
+}
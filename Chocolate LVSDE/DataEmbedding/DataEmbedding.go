@@ -17,8 +17,17 @@
 
 package DataEmbedding
 
-import "github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+import (
+	"context"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+)
+
+const VersionOfChocolateLVSDE = "1.20"
+
+const DefaultRandomSeed int64 = 159720256358285954
+
+const DefaultVisualDensityAdjustmentParameter float64 = 0.9
 
 type DataEmbeddingTechnique interface {
-	EmbedData(dataAbstractionSet DataAbstraction.DataAbstractionSet)
+	EmbedData(ctx context.Context, dataAbstractionSet DataAbstraction.DataAbstractionSet) error
 }
@@ -18,20 +18,22 @@
 package DataEmbedding
 
 import (
+	"fmt"
 	"github.com/emirpasic/gods/queues/priorityqueue"
 	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
 	"math"
 	"math/rand"
 	"strconv"
 	"strings"
 )
 
-func EvaluateEmbedding(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbstraction.DataAbstractionUnitVisibility, numberOfNeighbours int, evaluationLayers []string, evaluationNeighboursLayers []string, precision int) []string {
+func EvaluateEmbedding(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbstraction.DataAbstractionUnitVisibility, numberOfNeighbours int, evaluationLayers []string, evaluationNeighboursLayers []string, precision int, tieBreakingRandomSeed int64) ([]string, error) {
 	numberOfDataAbstractionUnits := len(dataAbstractionUnitVisibilitiesToBeShuffled)
 	dataAbstractionUnitVisibilities := make([]*DataAbstraction.DataAbstractionUnitVisibility, numberOfDataAbstractionUnits)
 	copy(dataAbstractionUnitVisibilities, dataAbstractionUnitVisibilitiesToBeShuffled)
 
-	randomGenerator := rand.New(rand.NewSource(849662123548415231))
+	randomGenerator := rand.New(rand.NewSource(tieBreakingRandomSeed))
 	randomGenerator.Shuffle(len(dataAbstractionUnitVisibilities), func(i, j int) {
 		dataAbstractionUnitVisibilities[i], dataAbstractionUnitVisibilities[j] = dataAbstractionUnitVisibilities[j], dataAbstractionUnitVisibilities[i]
 	})
@@ -100,7 +102,7 @@ func EvaluateEmbedding(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbstra
 				for t := 0; t < numberOfNeighbours; t++ {
 					a, _ := queue.Dequeue()
 					if a == nil {
-						return []string{"(Not enough neighbours),(Not enough neighbours),(Not enough neighbours)", "(Not enough neighbours)"}
+						return []string{"(Not enough neighbours),(Not enough neighbours),(Not enough neighbours)", "(Not enough neighbours)"}, nil
 					}
 					neighbourIndex := a.([]int)[0]
 					if neighbourIndex != i {
@@ -124,7 +126,7 @@ func EvaluateEmbedding(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbstra
 			}
 
 			if maximumOccurrenceClassLabelNumber == -1 {
-				panic("Not finished successfully.")
+				return nil, fmt.Errorf("%w: no class label number could be predicted for data abstraction unit %d with class label number %d", ErrorHandling.ErrInvalidInput, dataAbstractionUnitVisibilities[i].DataAbstractionUnitNumber, dataAbstractionUnitVisibilities[i].ClassLabelNumber)
 			}
 
 			if maximumOccurrenceClassLabelNumber == int(dataAbstractionUnitVisibilities[i].ClassLabelNumber) {
@@ -158,5 +160,54 @@ func EvaluateEmbedding(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbstra
 	}
 
 	return []string{strconv.FormatFloat(percent, 'f', precision, 64) + "%," +
-		strconv.Itoa(corrects) + "," + strconv.Itoa(incorrects), confusionMatrixCSV.String()}
+		strconv.Itoa(corrects) + "," + strconv.Itoa(incorrects), confusionMatrixCSV.String()}, nil
+}
+
+func EvaluateEmbeddingForReport(dataAbstractionUnitVisibilities []*DataAbstraction.DataAbstractionUnitVisibility, evaluationNeighbourhoodSize int, embeddingTechniqueName string, report *strings.Builder, confusionMatrices *strings.Builder, tieBreakingRandomSeed int64) (string, error) {
+	isRedGray := false
+	for _, dataAbstractionUnitVisibility := range dataAbstractionUnitVisibilities {
+		if dataAbstractionUnitVisibility.Layer == "red" || dataAbstractionUnitVisibility.Layer == "gray" {
+			isRedGray = true
+			break
+		}
+	}
+
+	evaluationLayersList := [][]string{{"NA"}}
+	evaluationNeighboursLayersList := [][]string{{"NA"}}
+	if isRedGray {
+		evaluationLayersList = [][]string{{"red", "gray"}, {"red", "gray"}, {"red"}, {"gray"}, {"gray"}, {"gray"}}
+		evaluationNeighboursLayersList = [][]string{{"red", "gray"}, {"red"}, {"red"}, {"gray"}, {"red"}, {"red", "gray"}}
+	}
+
+	var firstAccuracy string
+
+	for i := 0; i < len(evaluationLayersList); i++ {
+		evaluation, err := EvaluateEmbedding(dataAbstractionUnitVisibilities, evaluationNeighbourhoodSize, evaluationLayersList[i], evaluationNeighboursLayersList[i], 3, tieBreakingRandomSeed)
+		if err != nil {
+			return "", err
+		}
+
+		evaluationLayers := "(" + strings.Join(evaluationLayersList[i], "_and_") + ")"
+		evaluationNeighboursLayers := "(" + strings.Join(evaluationNeighboursLayersList[i], "_and_") + ")"
+		statisticalEvaluationType := "KNN_accuracy"
+		if isRedGray {
+			statisticalEvaluationType = "KNN_accuracy_" + evaluationLayers + "_" + evaluationNeighboursLayers
+		} else {
+			evaluationLayers = "NA"
+			evaluationNeighboursLayers = "NA"
+		}
+
+		report.WriteString(statisticalEvaluationType + "," + strconv.Itoa(evaluationNeighbourhoodSize) + "," + embeddingTechniqueName + "," +
+			evaluation[0] + "\r\n")
+		confusionMatrices.WriteString("Evaluation layers: " + evaluationLayers + "\r\nClassification layers: " + evaluationNeighboursLayers + "\r\nEvaluation neighbourhood size: " + strconv.Itoa(evaluationNeighbourhoodSize) + "\r\nEmbedding technique: " + embeddingTechniqueName + "\r\nConfusion matrix:\r\n" +
+			evaluation[1] + "______________________\r\n")
+
+		if i == 0 {
+			firstAccuracy = strings.Split(evaluation[0], ",")[0]
+		}
+	}
+
+	report.WriteString("#, #, #, #\r\n")
+
+	return firstAccuracy, nil
 }
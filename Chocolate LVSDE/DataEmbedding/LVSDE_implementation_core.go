@@ -18,17 +18,19 @@
 package DataEmbedding
 
 import (
+	"context"
 	"fmt"
-	"github.com/emirpasic/gods/queues/priorityqueue"
 	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
 	"math"
 	"math/rand"
 	"runtime"
 	"sync"
-	"time"
 )
 
 type DataEmbeddingTechniqueLVSDE struct {
+	Context                                         context.Context
 	VisualDensityAdjustmentParameter                float64
 	NumberOfNeighboursForBuildingNeighbourhoodGraph int32
 	NumberOfParallelSlices                          int32
@@ -43,7 +45,6 @@ type DataEmbeddingTechniqueLVSDE struct {
 	OriginalSpaceMaximumTransformedDistance         float64
 	VisualSpaceMaximumDistanceFirstIteration        float64
 	Iteration                                       int32
-	TemperatureAdjustment                           int32
 	Temperature                                     float64
 	InitialTemperature                              float64
 	GrayLayerDataAbstractionUnitCapacity            int32
@@ -56,121 +57,233 @@ type DataEmbeddingTechniqueLVSDE struct {
 	FrameLowY                                       float64
 	FrameHighY                                      float64
 	RandomSeed                                      int64
+	PhaseSchedule                                   PhaseSchedule
+	CoolingSchedule                                 CoolingSchedule
+	ConvergenceCriterion                            *ConvergenceCriterion
+	IterationOffset                                 int32
+	NumberOfConsecutiveConvergedIterations          int32
+	HasPhaseConverged                               bool
+	RepulsiveForces                                 RepulsiveForceConfiguration
+	Quadtree                                        *Quadtree
+	Multilevel                                      *MultilevelConfiguration
+	NearestNeighbours                               NearestNeighbourConfiguration
+	DistanceStorage                                 string
+	ProgressObservers                               []ProgressObserver
 }
 
-func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) EmbedData(dataAbstractionSet DataAbstraction.DataAbstractionSet) {
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) EmbedData(ctx context.Context, dataAbstractionSet DataAbstraction.DataAbstractionSet) error {
 
+	dataEmbeddingTechniqueLVSDE.Context = ctx
+	if !dataEmbeddingTechniqueLVSDE.PhaseSchedule.IsSet() {
+		dataEmbeddingTechniqueLVSDE.PhaseSchedule = DefaultPhaseSchedule()
+	}
+	if dataEmbeddingTechniqueLVSDE.CoolingSchedule == nil {
+		dataEmbeddingTechniqueLVSDE.CoolingSchedule = LinearCoolingSchedule{}
+	}
 	dataEmbeddingTechniqueLVSDE.CurrentPhase = 1
+	dataEmbeddingTechniqueLVSDE.IterationOffset = 0
+	dataEmbeddingTechniqueLVSDE.NumberOfConsecutiveConvergedIterations = 0
+	dataEmbeddingTechniqueLVSDE.HasPhaseConverged = false
 	dataEmbeddingTechniqueLVSDE.InitialTemperature = 100.0
-	dataEmbeddingTechniqueLVSDE.TemperatureAdjustment = -1
 	dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitCapacity = -1
 	dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitSize = 0
 	dataEmbeddingTechniqueLVSDE.Width = 1000.0
 	dataEmbeddingTechniqueLVSDE.Height = 1000.0
-	var numberOfIterations int32 = 1830
-	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations = make([][]*DataAbstraction.DataAbstractionUnitVisibility, numberOfIterations)
+	numberOfIterations := dataEmbeddingTechniqueLVSDE.PhaseSchedule.NumberOfIterations()
 	dataEmbeddingTechniqueLVSDE.DataAbstractionSet = &dataAbstractionSet
 
-	dataEmbeddingTechniqueLVSDE.PerformStartingCalculation()
+	err := dataEmbeddingTechniqueLVSDE.PerformStartingCalculation()
+	if err != nil {
+		return err
+	}
 
-	for dataEmbeddingTechniqueLVSDE.Iteration = 1; dataEmbeddingTechniqueLVSDE.Iteration <= numberOfIterations; dataEmbeddingTechniqueLVSDE.Iteration++ {
-		if dataEmbeddingTechniqueLVSDE.Iteration%300 == 0 || dataEmbeddingTechniqueLVSDE.Iteration == 1 || dataEmbeddingTechniqueLVSDE.Iteration == numberOfIterations {
-			fmt.Printf("LVSDE iteration %04d starting at %s\n", int(dataEmbeddingTechniqueLVSDE.Iteration), time.Now().Format(time.UnixDate))
+	if dataEmbeddingTechniqueLVSDE.Multilevel != nil {
+		err = dataEmbeddingTechniqueLVSDE.PerformMultilevelLayout()
+		if err != nil {
+			return err
 		}
+	}
 
-		dataEmbeddingTechniqueLVSDE.Temperature = dataEmbeddingTechniqueLVSDE.InitialTemperature - (float64(dataEmbeddingTechniqueLVSDE.Iteration-dataEmbeddingTechniqueLVSDE.TemperatureAdjustment)/1000.0)*dataEmbeddingTechniqueLVSDE.InitialTemperature
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations = make([][]*DataAbstraction.DataAbstractionUnitVisibility, numberOfIterations)
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.NumbersOfIterationsOfPhases = append([]int32(nil), dataEmbeddingTechniqueLVSDE.PhaseSchedule.NumbersOfIterationsOfPhases[:]...)
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.Temperatures = make([]float64, 0, numberOfIterations)
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.LastIterationsOfPhases = make([]int32, 0, NumberOfPhases)
+	var meanDisplacement float64 = 0
+	dataEmbeddingTechniqueLVSDE.notifyPhaseChanged(0)
+
+	for dataEmbeddingTechniqueLVSDE.Iteration = 1; dataEmbeddingTechniqueLVSDE.Iteration+dataEmbeddingTechniqueLVSDE.IterationOffset <= numberOfIterations; dataEmbeddingTechniqueLVSDE.Iteration++ {
+		if ctx.Err() != nil {
+			return dataEmbeddingTechniqueLVSDE.MarkAsCancelled(ctx.Err())
+		}
 
-		var i, j int32
+		dataEmbeddingTechniqueLVSDE.Temperature = dataEmbeddingTechniqueLVSDE.CoolingSchedule.Temperature(CoolingState{
+			Iteration:                           dataEmbeddingTechniqueLVSDE.Iteration + dataEmbeddingTechniqueLVSDE.IterationOffset,
+			Phase:                               dataEmbeddingTechniqueLVSDE.CurrentPhase,
+			InitialTemperature:                  dataEmbeddingTechniqueLVSDE.InitialTemperature,
+			PhaseSchedule:                       &dataEmbeddingTechniqueLVSDE.PhaseSchedule,
+			MeanDisplacementOfPreviousIteration: meanDisplacement,
+			FirstIterationOfRun:                 dataEmbeddingTechniqueLVSDE.IterationOffset + 1,
+		})
+
+		meanDisplacement, err = dataEmbeddingTechniqueLVSDE.CalculateForcesAndMoveVisualSpaceProjections()
+		if err != nil {
+			if ctx.Err() != nil {
+				return dataEmbeddingTechniqueLVSDE.MarkAsCancelled(ctx.Err())
+			}
+			return err
+		}
+
+		var i int32
 		var numberOfDataAbstractionUnits int32 = int32(len(dataAbstractionSet.DataAbstractionUnits))
-		for i = 0; i < numberOfDataAbstractionUnits; i++ {
-			for j = 0; j < int32(len(dataAbstractionSet.DataAbstractionUnits[i].TemporaryVisualSpaceCoordinates)); j++ {
-				dataAbstractionSet.DataAbstractionUnits[i].TemporaryVisualSpaceCoordinates[j][0] = 0
-				dataAbstractionSet.DataAbstractionUnits[i].TemporaryVisualSpaceCoordinates[j][1] = 0
 
-				dataAbstractionSet.DataAbstractionUnits[i].VisualSpacePositiveReplicationPressuresPerAxis[j] = [36]float64{}
-				dataAbstractionSet.DataAbstractionUnits[i].VisualSpaceNegativeReplicationPressuresPerAxis[j] = [36]float64{}
+		if dataEmbeddingTechniqueLVSDE.CurrentPhase == 2 {
+			if dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitCapacity == -1 {
+				dataEmbeddingTechniqueLVSDE.CalculateGrayLayerCapacity()
 			}
-		}
 
-		dataEmbeddingTechniqueLVSDE.WaitGroup.Add(int(dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices))
-		for i = 0; i < dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices; i++ {
-			go dataEmbeddingTechniqueLVSDE.CalculateRepulsiveForcesSlice(i)
+			if dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitSize < dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitCapacity {
+				dataEmbeddingTechniqueLVSDE.MoveAndFreezeOneDataAbstractionUnitToGrayLayer()
+			}
 		}
-		dataEmbeddingTechniqueLVSDE.WaitGroup.Wait()
 
-		dataEmbeddingTechniqueLVSDE.WaitGroup.Add(int(dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices))
-		for i = 0; i < dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices; i++ {
-			go dataEmbeddingTechniqueLVSDE.CalculateAttractiveForcesSlice1(i)
+		dataEmbeddingTechniqueLVSDE.EmbeddingDetails.Temperatures = append(dataEmbeddingTechniqueLVSDE.EmbeddingDetails.Temperatures, dataEmbeddingTechniqueLVSDE.Temperature)
+		dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations[dataEmbeddingTechniqueLVSDE.Iteration-1] = make([]*DataAbstraction.DataAbstractionUnitVisibility, numberOfDataAbstractionUnits)
+		for i = 0; i < numberOfDataAbstractionUnits; i++ {
+			dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations[dataEmbeddingTechniqueLVSDE.Iteration-1][i] = dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].ToDataAbstractionUnitVisibility(dataEmbeddingTechniqueLVSDE.Iteration, "LVSDE")
 		}
-		dataEmbeddingTechniqueLVSDE.WaitGroup.Wait()
 
-		dataEmbeddingTechniqueLVSDE.WaitGroup.Add(int(dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices))
-		for i = 0; i < dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices; i++ {
-			go dataEmbeddingTechniqueLVSDE.CalculateAttractiveForcesSlice2(i)
+		iterationCompletedEvent := IterationCompletedEvent{
+			Iteration:          dataEmbeddingTechniqueLVSDE.Iteration,
+			NumberOfIterations: numberOfIterations - dataEmbeddingTechniqueLVSDE.IterationOffset,
+			Temperature:        dataEmbeddingTechniqueLVSDE.Temperature,
+			Phase:              dataEmbeddingTechniqueLVSDE.CurrentPhase,
+			MeanDisplacement:   meanDisplacement,
+		}
+		for _, progressObserver := range dataEmbeddingTechniqueLVSDE.ProgressObservers {
+			progressObserver.IterationCompleted(iterationCompletedEvent)
 		}
-		dataEmbeddingTechniqueLVSDE.WaitGroup.Wait()
 
-		for i = 0; i < numberOfDataAbstractionUnits; i++ {
-			dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[i]
+		dataEmbeddingTechniqueLVSDE.UpdateConvergence(meanDisplacement)
+		dataEmbeddingTechniqueLVSDE.ChangePhaseIfRequired()
+	}
 
-			if dataAbstractionUnit.AreAllVisualSpaceProjectionsFrozen {
-				continue
-			}
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations = dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations[:dataEmbeddingTechniqueLVSDE.Iteration-1]
 
-			for j = 0; j < int32(len(dataAbstractionUnit.TemporaryVisualSpaceCoordinates)); j++ {
-				length := math.Sqrt(math.Pow(dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][0], 2) + math.Pow(dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][1], 2))
-				if length < dataEmbeddingTechniqueLVSDE.Temperature {
-					dataAbstractionUnit.VisualSpaceCoordinates[j][0] += dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][0]
-					dataAbstractionUnit.VisualSpaceCoordinates[j][1] += dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][1]
-				} else {
-					dataAbstractionUnit.VisualSpaceCoordinates[j][0] += dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][0] * (dataEmbeddingTechniqueLVSDE.Temperature / length)
-					dataAbstractionUnit.VisualSpaceCoordinates[j][1] += dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][1] * (dataEmbeddingTechniqueLVSDE.Temperature / length)
-				}
+	return nil
+}
 
-				x := dataAbstractionUnit.VisualSpaceCoordinates[j][0]
-				y := dataAbstractionUnit.VisualSpaceCoordinates[j][1]
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) CalculateForcesAndMoveVisualSpaceProjections() (float64, error) {
+	var i, j int32
+	var numberOfDataAbstractionUnits int32 = int32(len(dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits))
+	for i = 0; i < numberOfDataAbstractionUnits; i++ {
+		for j = 0; j < int32(len(dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].TemporaryVisualSpaceCoordinates)); j++ {
+			dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].TemporaryVisualSpaceCoordinates[j][0] = 0
+			dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].TemporaryVisualSpaceCoordinates[j][1] = 0
 
-				if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
-					panic("Not finished successfully. Unstable floating point calculations.")
-				}
+			dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].VisualSpacePositiveReplicationPressuresPerAxis[j] = [36]float64{}
+			dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].VisualSpaceNegativeReplicationPressuresPerAxis[j] = [36]float64{}
+		}
+	}
 
-				if dataEmbeddingTechniqueLVSDE.CurrentPhase >= 2 {
-					if dataAbstractionUnit.VisualSpaceCoordinates[j][0] < dataEmbeddingTechniqueLVSDE.FrameLowX {
-						dataAbstractionUnit.VisualSpaceCoordinates[j][0] = dataEmbeddingTechniqueLVSDE.FrameLowX
-					}
+	if dataEmbeddingTechniqueLVSDE.RepulsiveForces.Method == RepulsiveForceMethodBarnesHut {
+		dataEmbeddingTechniqueLVSDE.BuildQuadtree()
+	}
 
-					if dataAbstractionUnit.VisualSpaceCoordinates[j][0] > dataEmbeddingTechniqueLVSDE.FrameHighX {
-						dataAbstractionUnit.VisualSpaceCoordinates[j][0] = dataEmbeddingTechniqueLVSDE.FrameHighX
-					}
+	dataEmbeddingTechniqueLVSDE.WaitGroup.Add(int(dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices))
+	for i = 0; i < dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices; i++ {
+		if dataEmbeddingTechniqueLVSDE.RepulsiveForces.Method == RepulsiveForceMethodBarnesHut {
+			go dataEmbeddingTechniqueLVSDE.CalculateRepulsiveForcesSliceBarnesHut(i)
+		} else {
+			go dataEmbeddingTechniqueLVSDE.CalculateRepulsiveForcesSlice(i)
+		}
+	}
+	dataEmbeddingTechniqueLVSDE.WaitGroup.Wait()
+	if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+		return 0, dataEmbeddingTechniqueLVSDE.Context.Err()
+	}
 
-					if dataAbstractionUnit.VisualSpaceCoordinates[j][1] < dataEmbeddingTechniqueLVSDE.FrameLowY {
-						dataAbstractionUnit.VisualSpaceCoordinates[j][1] = dataEmbeddingTechniqueLVSDE.FrameLowY
-					}
+	dataEmbeddingTechniqueLVSDE.WaitGroup.Add(int(dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices))
+	for i = 0; i < dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices; i++ {
+		go dataEmbeddingTechniqueLVSDE.CalculateAttractiveForcesSlice1(i)
+	}
+	dataEmbeddingTechniqueLVSDE.WaitGroup.Wait()
+	if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+		return 0, dataEmbeddingTechniqueLVSDE.Context.Err()
+	}
 
-					if dataAbstractionUnit.VisualSpaceCoordinates[j][1] > dataEmbeddingTechniqueLVSDE.FrameHighY {
-						dataAbstractionUnit.VisualSpaceCoordinates[j][1] = dataEmbeddingTechniqueLVSDE.FrameHighY
-					}
-				}
-			}
+	dataEmbeddingTechniqueLVSDE.WaitGroup.Add(int(dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices))
+	for i = 0; i < dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices; i++ {
+		go dataEmbeddingTechniqueLVSDE.CalculateAttractiveForcesSlice2(i)
+	}
+	dataEmbeddingTechniqueLVSDE.WaitGroup.Wait()
+	if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+		return 0, dataEmbeddingTechniqueLVSDE.Context.Err()
+	}
+
+	var totalDisplacement float64 = 0
+	var numberOfDisplacedProjections int32 = 0
+
+	for i = 0; i < numberOfDataAbstractionUnits; i++ {
+		dataAbstractionUnit := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i]
+
+		if dataAbstractionUnit.AreAllVisualSpaceProjectionsFrozen {
+			continue
 		}
 
-		if dataEmbeddingTechniqueLVSDE.CurrentPhase == 2 {
-			if dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitCapacity == -1 {
-				dataEmbeddingTechniqueLVSDE.CalculateGrayLayerCapacity()
+		for j = 0; j < int32(len(dataAbstractionUnit.TemporaryVisualSpaceCoordinates)); j++ {
+			length := math.Sqrt(math.Pow(dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][0], 2) + math.Pow(dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][1], 2))
+			if length < dataEmbeddingTechniqueLVSDE.Temperature {
+				dataAbstractionUnit.VisualSpaceCoordinates[j][0] += dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][0]
+				dataAbstractionUnit.VisualSpaceCoordinates[j][1] += dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][1]
+				totalDisplacement += length
+			} else {
+				dataAbstractionUnit.VisualSpaceCoordinates[j][0] += dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][0] * (dataEmbeddingTechniqueLVSDE.Temperature / length)
+				dataAbstractionUnit.VisualSpaceCoordinates[j][1] += dataAbstractionUnit.TemporaryVisualSpaceCoordinates[j][1] * (dataEmbeddingTechniqueLVSDE.Temperature / length)
+				totalDisplacement += math.Abs(dataEmbeddingTechniqueLVSDE.Temperature)
 			}
+			numberOfDisplacedProjections++
 
-			if dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitSize < dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitCapacity {
-				dataEmbeddingTechniqueLVSDE.MoveAndFreezeOneDataAbstractionUnitToGrayLayer()
+			x := dataAbstractionUnit.VisualSpaceCoordinates[j][0]
+			y := dataAbstractionUnit.VisualSpaceCoordinates[j][1]
+
+			if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
+				return 0, fmt.Errorf("%w: data abstraction unit %d has coordinates (%g, %g) in iteration %d", ErrorHandling.ErrUnstableFloatingPoint, dataAbstractionUnit.DataAbstractionUnitNumber, x, y, dataEmbeddingTechniqueLVSDE.Iteration)
 			}
-		}
 
-		dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations[dataEmbeddingTechniqueLVSDE.Iteration-1] = make([]*DataAbstraction.DataAbstractionUnitVisibility, numberOfDataAbstractionUnits)
-		for i = 0; i < numberOfDataAbstractionUnits; i++ {
-			dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations[dataEmbeddingTechniqueLVSDE.Iteration-1][i] = dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].ToDataAbstractionUnitVisibility(dataEmbeddingTechniqueLVSDE.Iteration, "LVSDE")
+			if dataEmbeddingTechniqueLVSDE.CurrentPhase >= 2 {
+				if dataAbstractionUnit.VisualSpaceCoordinates[j][0] < dataEmbeddingTechniqueLVSDE.FrameLowX {
+					dataAbstractionUnit.VisualSpaceCoordinates[j][0] = dataEmbeddingTechniqueLVSDE.FrameLowX
+				}
+
+				if dataAbstractionUnit.VisualSpaceCoordinates[j][0] > dataEmbeddingTechniqueLVSDE.FrameHighX {
+					dataAbstractionUnit.VisualSpaceCoordinates[j][0] = dataEmbeddingTechniqueLVSDE.FrameHighX
+				}
+
+				if dataAbstractionUnit.VisualSpaceCoordinates[j][1] < dataEmbeddingTechniqueLVSDE.FrameLowY {
+					dataAbstractionUnit.VisualSpaceCoordinates[j][1] = dataEmbeddingTechniqueLVSDE.FrameLowY
+				}
+
+				if dataAbstractionUnit.VisualSpaceCoordinates[j][1] > dataEmbeddingTechniqueLVSDE.FrameHighY {
+					dataAbstractionUnit.VisualSpaceCoordinates[j][1] = dataEmbeddingTechniqueLVSDE.FrameHighY
+				}
+			}
 		}
+	}
 
-		dataEmbeddingTechniqueLVSDE.ChangePhaseIfRequired()
+	var meanDisplacement float64 = 0
+	if numberOfDisplacedProjections > 0 {
+		meanDisplacement = totalDisplacement / float64(numberOfDisplacedProjections)
 	}
+	return meanDisplacement, nil
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) MarkAsCancelled(err error) error {
+	lastCompletedIteration := dataEmbeddingTechniqueLVSDE.Iteration - 1
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations = dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations[:lastCompletedIteration]
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.IsCancelled = true
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.LastCompletedIteration = lastCompletedIteration
+	Logging.Warn("LVSDE cancelled.", Logging.Fields{"last_completed_iteration": lastCompletedIteration})
+	return ErrorHandling.NewCancellationError("running LVSDE iterations", lastCompletedIteration, err)
 }
 
 func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) CalculateRepulsiveForcesSlice(sliceNumber int32) {
@@ -179,6 +292,10 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) CalculateRepulsi
 	var numberOfDataAbstractionUnits int32 = int32(len(dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits))
 	var i, j, k, l int32
 	for i = sliceNumber; i < numberOfDataAbstractionUnits; i += dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices {
+		if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+			return
+		}
+
 		dataAbstractionUnit1 := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i]
 
 		for j = 0; j < int32(len(dataAbstractionUnit1.VisualSpaceCoordinates)); j++ {
@@ -237,6 +354,10 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) CalculateAttract
 	var numberOfDataAbstractionUnits int32 = int32(len(dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits))
 	var i, j, k, l int32
 	for i = sliceNumber; i < numberOfDataAbstractionUnits; i += dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices {
+		if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+			return
+		}
+
 		dataAbstractionUnit1 := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i]
 		dataAbstractionUnit1Index := dataAbstractionUnit1.DataAbstractionUnitNumber
 
@@ -268,7 +389,7 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) CalculateAttract
 					visualDistance = dataEmbeddingTechniqueLVSDE.Epsilon
 				}
 
-				originalSpaceTransformedDistance := dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistancesAfterTransformation[dataAbstractionUnit1Index][dataAbstractionUnit2Index]
+				originalSpaceTransformedDistance := dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistanceAfterTransformation(dataAbstractionUnit1Index, dataAbstractionUnit2Index)
 
 				attractiveMagnitude1 := visualDistance / dataEmbeddingTechniqueLVSDE.BaseDistance
 				attractiveMagnitude1 = math.Pow(attractiveMagnitude1, 1-dataEmbeddingTechniqueLVSDE.VisualDensityAdjustmentParameter)
@@ -312,6 +433,10 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) CalculateAttract
 	var numberOfDataAbstractionUnits int32 = int32(len(dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits))
 	var i, j, k, l int32
 	for i = 0; i < numberOfDataAbstractionUnits; i++ {
+		if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+			return
+		}
+
 		dataAbstractionUnit1 := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i]
 		dataAbstractionUnit1Index := dataAbstractionUnit1.DataAbstractionUnitNumber
 
@@ -348,7 +473,7 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) CalculateAttract
 					visualDistance = dataEmbeddingTechniqueLVSDE.Epsilon
 				}
 
-				originalSpaceTransformedDistance := dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistancesAfterTransformation[dataAbstractionUnit1Index][dataAbstractionUnit2Index]
+				originalSpaceTransformedDistance := dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistanceAfterTransformation(dataAbstractionUnit1Index, dataAbstractionUnit2Index)
 
 				attractiveMagnitude1 := visualDistance / dataEmbeddingTechniqueLVSDE.BaseDistance
 				attractiveMagnitude1 = math.Pow(attractiveMagnitude1, 1-dataEmbeddingTechniqueLVSDE.VisualDensityAdjustmentParameter)
@@ -387,7 +512,20 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) CalculateAttract
 }
 
 func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) ChangePhaseIfRequired() {
-	if dataEmbeddingTechniqueLVSDE.Iteration == 500 {
+	oldPhase := dataEmbeddingTechniqueLVSDE.CurrentPhase
+
+	phaseSchedule := &dataEmbeddingTechniqueLVSDE.PhaseSchedule
+
+	if dataEmbeddingTechniqueLVSDE.Iteration+dataEmbeddingTechniqueLVSDE.IterationOffset != phaseSchedule.LastIterationOfPhase(oldPhase) && !dataEmbeddingTechniqueLVSDE.HasPhaseConverged {
+		return
+	}
+
+	dataEmbeddingTechniqueLVSDE.IterationOffset = phaseSchedule.LastIterationOfPhase(oldPhase) - dataEmbeddingTechniqueLVSDE.Iteration
+	dataEmbeddingTechniqueLVSDE.NumberOfConsecutiveConvergedIterations = 0
+	dataEmbeddingTechniqueLVSDE.HasPhaseConverged = false
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.LastIterationsOfPhases = append(dataEmbeddingTechniqueLVSDE.EmbeddingDetails.LastIterationsOfPhases, dataEmbeddingTechniqueLVSDE.Iteration)
+
+	if oldPhase == 1 {
 		dataEmbeddingTechniqueLVSDE.CurrentPhase = 2
 
 		dataAbstractionUnits := dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits
@@ -419,65 +557,34 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) ChangePhaseIfReq
 		dataEmbeddingTechniqueLVSDE.FrameLowY = yLow - (yHigh-yLow)/20.0
 		dataEmbeddingTechniqueLVSDE.FrameHighY = yHigh + (yHigh-yLow)/20.0
 
-	} else if dataEmbeddingTechniqueLVSDE.Iteration == 950 {
+	} else if oldPhase == 2 {
 		dataEmbeddingTechniqueLVSDE.CurrentPhase = 3
-		dataEmbeddingTechniqueLVSDE.TemperatureAdjustment = 440
 		dataEmbeddingTechniqueLVSDE.UnfreezeAndMarkEffectiveGrayLayer()
 		dataEmbeddingTechniqueLVSDE.FreezeRedLayer()
-	} else if dataEmbeddingTechniqueLVSDE.Iteration == 1340 {
+	} else if oldPhase == 3 {
 		dataEmbeddingTechniqueLVSDE.CurrentPhase = 4
-		dataEmbeddingTechniqueLVSDE.TemperatureAdjustment = 830
 		dataEmbeddingTechniqueLVSDE.SplitVerticesOfGrayLayerIfPossible()
 	}
+
+	if dataEmbeddingTechniqueLVSDE.CurrentPhase != oldPhase {
+		dataEmbeddingTechniqueLVSDE.notifyPhaseChanged(oldPhase)
+	}
 }
 
-func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) PerformStartingCalculation() {
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) PerformStartingCalculation() error {
 	dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices = int32(runtime.NumCPU()) - 1
-	fmt.Println("Number of parallel goroutines:", dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices)
-	dataEmbeddingTechniqueLVSDE.DataAbstractionSet.ComputeDistancesAfterTransformation()
+	if dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices < 1 {
+		dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices = 1
+	}
+	Logging.Info("Starting calculation.", Logging.Fields{"number_of_parallel_goroutines": dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices})
+	err := dataEmbeddingTechniqueLVSDE.BuildNeighbourhoodGraph()
+	if err != nil {
+		return err
+	}
 
 	var numberOfDataAbstractionUnits int32 = int32(len(dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits))
 	var i, j int32
 
-	for i = 0; i < numberOfDataAbstractionUnits; i++ {
-		dataAbstractionUnit := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i]
-
-		originalSpaceTransformedDistanceCompare := func(a, b interface{}) int {
-			indexA := a.(*DataAbstraction.DataAbstractionUnit).DataAbstractionUnitNumber
-			indexB := b.(*DataAbstraction.DataAbstractionUnit).DataAbstractionUnitNumber
-			if dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistancesAfterTransformation[i][indexA] < dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistancesAfterTransformation[i][indexB] {
-				return -1
-			} else if dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistancesAfterTransformation[i][indexA] == dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistancesAfterTransformation[i][indexB] {
-				return 0
-			} else {
-				return 1
-			}
-		}
-
-		queue := priorityqueue.NewWith(originalSpaceTransformedDistanceCompare)
-
-		for j = 0; j < numberOfDataAbstractionUnits; j++ {
-			if i == j {
-				continue
-			}
-			dataAbstractionUnit2 := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[j]
-			queue.Enqueue(dataAbstractionUnit2)
-		}
-
-		dataAbstractionUnit.NeighbourIndices = make([][][2]int32, 1)
-		dataAbstractionUnit.NeighbourIndices[0] = make([][2]int32, dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph)
-		for j = 0; j < dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph; j++ {
-			dataAbstractionUnit2, _ := queue.Dequeue()
-			if dataAbstractionUnit == nil {
-				panic("Not finished successfully.")
-			}
-
-			neighbourIndex := dataAbstractionUnit2.(*DataAbstraction.DataAbstractionUnit).DataAbstractionUnitNumber
-			dataAbstractionUnit.NeighbourIndices[0][j][0] = neighbourIndex
-			dataAbstractionUnit.NeighbourIndices[0][j][1] = 0
-		}
-	}
-
 	randomSource := rand.NewSource(dataEmbeddingTechniqueLVSDE.RandomSeed)
 	randomGenerator := rand.New(randomSource)
 	for i = 0; i < numberOfDataAbstractionUnits; i++ {
@@ -495,18 +602,33 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) PerformStartingC
 	dataEmbeddingTechniqueLVSDE.OriginalSpaceMaximumTransformedDistance = 0.0
 	dataEmbeddingTechniqueLVSDE.VisualSpaceMaximumDistanceFirstIteration = 0.0
 
-	for i = 0; i < numberOfDataAbstractionUnits; i++ {
-		for j = 0; j < numberOfDataAbstractionUnits; j++ {
-			visualSpaceCoordinates1 := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].VisualSpaceCoordinates[0]
-			visualSpaceCoordinates2 := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[j].VisualSpaceCoordinates[0]
+	distancesAfterTransformation := dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistancesAfterTransformation
+	if distancesAfterTransformation == nil {
+		dataEmbeddingTechniqueLVSDE.OriginalSpaceMaximumTransformedDistance = dataEmbeddingTechniqueLVSDE.DataAbstractionSet.EstimateMaximumDistanceAfterTransformation(NumberOfSampledPairsPerDataAbstractionUnitForMaximumDistanceEstimation, DeriveSeed(dataEmbeddingTechniqueLVSDE.RandomSeed, SeedStreamDistanceEstimation))
+
+		visualSpaceCoordinates := make([][2]float64, numberOfDataAbstractionUnits)
+		for i = 0; i < numberOfDataAbstractionUnits; i++ {
+			visualSpaceCoordinates[i] = dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].VisualSpaceCoordinates[0]
+		}
+		dataEmbeddingTechniqueLVSDE.VisualSpaceMaximumDistanceFirstIteration = MaximumDistanceBetweenPoints(visualSpaceCoordinates)
+	} else {
+		for i = 0; i < numberOfDataAbstractionUnits; i++ {
+			if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+				return ErrorHandling.NewCancellationError("computing maximum distances", 0, dataEmbeddingTechniqueLVSDE.Context.Err())
+			}
 
-			horizontalDifference := visualSpaceCoordinates1[0] - visualSpaceCoordinates2[0]
-			verticalDifference := visualSpaceCoordinates1[1] - visualSpaceCoordinates2[1]
-			visualDistance := math.Sqrt(math.Pow(horizontalDifference, 2) + math.Pow(verticalDifference, 2))
+			for j = 0; j < numberOfDataAbstractionUnits; j++ {
+				visualSpaceCoordinates1 := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[i].VisualSpaceCoordinates[0]
+				visualSpaceCoordinates2 := &dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[j].VisualSpaceCoordinates[0]
+
+				horizontalDifference := visualSpaceCoordinates1[0] - visualSpaceCoordinates2[0]
+				verticalDifference := visualSpaceCoordinates1[1] - visualSpaceCoordinates2[1]
+				visualDistance := math.Sqrt(math.Pow(horizontalDifference, 2) + math.Pow(verticalDifference, 2))
 
-			dataEmbeddingTechniqueLVSDE.OriginalSpaceMaximumTransformedDistance = math.Max(dataEmbeddingTechniqueLVSDE.OriginalSpaceMaximumTransformedDistance, dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DistancesAfterTransformation[i][j])
-			dataEmbeddingTechniqueLVSDE.VisualSpaceMaximumDistanceFirstIteration = math.Max(dataEmbeddingTechniqueLVSDE.VisualSpaceMaximumDistanceFirstIteration, visualDistance)
+				dataEmbeddingTechniqueLVSDE.OriginalSpaceMaximumTransformedDistance = math.Max(dataEmbeddingTechniqueLVSDE.OriginalSpaceMaximumTransformedDistance, distancesAfterTransformation[i][j])
+				dataEmbeddingTechniqueLVSDE.VisualSpaceMaximumDistanceFirstIteration = math.Max(dataEmbeddingTechniqueLVSDE.VisualSpaceMaximumDistanceFirstIteration, visualDistance)
 
+			}
 		}
 	}
 
@@ -514,6 +636,8 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) PerformStartingC
 		dataEmbeddingTechniqueLVSDE.PrecomputedCosineOfAxisAngle[axis] = math.Cos(math.Pi * float64(axis) * 10.0 / 180.0)
 		dataEmbeddingTechniqueLVSDE.PrecomputedSineOfAxisAngle[axis] = math.Sin(math.Pi * float64(axis) * 10.0 / 180.0)
 	}
+
+	return nil
 }
 
 func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) SplitVerticesOfGrayLayerIfPossible() {
@@ -643,6 +767,17 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) MoveAndFreezeOne
 	dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[indexMaximum].AreAllVisualSpaceProjectionsIneffective = true
 	dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[indexMaximum].AreAllVisualSpaceProjectionsFrozen = true
 	dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitSize++
+
+	movedToGrayLayerEvent := DataAbstractionUnitMovedToGrayLayerEvent{
+		Iteration:                            dataEmbeddingTechniqueLVSDE.Iteration,
+		DataAbstractionUnitNumber:            dataEmbeddingTechniqueLVSDE.DataAbstractionSet.DataAbstractionUnits[indexMaximum].DataAbstractionUnitNumber,
+		MaximumPressure:                      maximumPressureAll,
+		GrayLayerDataAbstractionUnitCapacity: dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitCapacity,
+		GrayLayerDataAbstractionUnitSize:     dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitSize,
+	}
+	for _, progressObserver := range dataEmbeddingTechniqueLVSDE.ProgressObservers {
+		progressObserver.DataAbstractionUnitMovedToGrayLayer(movedToGrayLayerEvent)
+	}
 }
 
 func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) SplitVertex(dataAbstractionUnit *DataAbstraction.DataAbstractionUnit, index int32) {
@@ -660,6 +795,7 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) SplitVertex(data
 
 	if hasVertexSplitFailed {
 		dataAbstractionUnit.HasVertexSplitFailed = true
+		dataEmbeddingTechniqueLVSDE.notifyVertexSplit(dataAbstractionUnit.DataAbstractionUnitNumber, true, selectedAxis, 0, 0)
 		return
 	}
 
@@ -687,6 +823,7 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) SplitVertex(data
 
 	if len(visualNeighboursIndices1) == 0 || len(visualNeighboursIndices2) == 0 {
 		dataAbstractionUnit.HasVertexSplitFailed = true
+		dataEmbeddingTechniqueLVSDE.notifyVertexSplit(dataAbstractionUnit.DataAbstractionUnitNumber, true, selectedAxis, len(visualNeighboursIndices1), len(visualNeighboursIndices2))
 		return
 	}
 
@@ -713,4 +850,6 @@ func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) SplitVertex(data
 	y /= float64(len(visualNeighboursIndices2))
 	dataAbstractionUnit.VisualSpaceCoordinates[1][0] = x
 	dataAbstractionUnit.VisualSpaceCoordinates[1][1] = y
+
+	dataEmbeddingTechniqueLVSDE.notifyVertexSplit(dataAbstractionUnit.DataAbstractionUnitNumber, false, selectedAxis, len(visualNeighboursIndices1), len(visualNeighboursIndices2))
 }
@@ -0,0 +1,296 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const DefaultCoarsestLevelSize int32 = 1000
+
+const DefaultNumberOfRefinementIterations int32 = 100
+
+const MaximumNumberOfMembersOfSuperUnit int = 4
+
+const MaximumCoarseningRatio float64 = 0.9
+
+type MultilevelConfiguration struct {
+	CoarsestLevelSize            int32 `json:"coarsest_level_size"`
+	NumberOfRefinementIterations int32 `json:"number_of_refinement_iterations"`
+}
+
+func DefaultMultilevelConfiguration() MultilevelConfiguration {
+	return MultilevelConfiguration{
+		CoarsestLevelSize:            DefaultCoarsestLevelSize,
+		NumberOfRefinementIterations: DefaultNumberOfRefinementIterations,
+	}
+}
+
+type MultilevelLevel struct {
+	DataAbstractionSet           DataAbstraction.DataAbstractionSet
+	SuperUnitIndicesOfFinerLevel []int32
+	Members                      [][]int32
+}
+
+func CoarsenDataAbstractionSet(finerDataAbstractionSet *DataAbstraction.DataAbstractionSet, numberOfNeighbours int32, randomGenerator *rand.Rand) *MultilevelLevel {
+	finerDataAbstractionUnits := finerDataAbstractionSet.DataAbstractionUnits
+	numberOfFinerDataAbstractionUnits := len(finerDataAbstractionUnits)
+
+	level := new(MultilevelLevel)
+	level.SuperUnitIndicesOfFinerLevel = make([]int32, numberOfFinerDataAbstractionUnits)
+	for i := range level.SuperUnitIndicesOfFinerLevel {
+		level.SuperUnitIndicesOfFinerLevel[i] = -1
+	}
+
+	for _, i := range randomGenerator.Perm(numberOfFinerDataAbstractionUnits) {
+		if level.SuperUnitIndicesOfFinerLevel[i] >= 0 {
+			continue
+		}
+
+		neighbourIndices := finerDataAbstractionUnits[i].NeighbourIndices[0]
+		var superUnitIndex int32 = -1
+		for _, neighbourIndex := range neighbourIndices {
+			j := neighbourIndex[0]
+			if int(j) != i && level.SuperUnitIndicesOfFinerLevel[j] < 0 {
+				superUnitIndex = int32(len(level.Members))
+				level.Members = append(level.Members, []int32{int32(i), j})
+				level.SuperUnitIndicesOfFinerLevel[j] = superUnitIndex
+				break
+			}
+		}
+
+		if superUnitIndex < 0 {
+			for _, neighbourIndex := range neighbourIndices {
+				neighbourSuperUnitIndex := level.SuperUnitIndicesOfFinerLevel[neighbourIndex[0]]
+				if neighbourSuperUnitIndex >= 0 && len(level.Members[neighbourSuperUnitIndex]) < MaximumNumberOfMembersOfSuperUnit {
+					superUnitIndex = neighbourSuperUnitIndex
+					level.Members[superUnitIndex] = append(level.Members[superUnitIndex], int32(i))
+					break
+				}
+			}
+		}
+
+		if superUnitIndex < 0 {
+			superUnitIndex = int32(len(level.Members))
+			level.Members = append(level.Members, []int32{int32(i)})
+		}
+
+		level.SuperUnitIndicesOfFinerLevel[i] = superUnitIndex
+	}
+
+	numberOfSuperUnits := len(level.Members)
+	level.DataAbstractionSet.DistancesAfterTransformation = finerDataAbstractionSet.DistancesAfterTransformation
+	level.DataAbstractionSet.SparseDistancesAfterTransformation = finerDataAbstractionSet.SparseDistancesAfterTransformation
+	level.DataAbstractionSet.DataAbstractionUnits = make([]DataAbstraction.DataAbstractionUnit, numberOfSuperUnits)
+
+	numberOfNeighboursOfLevel := int(math.Round(float64(numberOfNeighbours) * float64(numberOfSuperUnits) / float64(numberOfFinerDataAbstractionUnits)))
+	if numberOfNeighboursOfLevel > numberOfSuperUnits-1 {
+		numberOfNeighboursOfLevel = numberOfSuperUnits - 1
+	}
+	if numberOfNeighboursOfLevel < 1 {
+		numberOfNeighboursOfLevel = 1
+	}
+
+	markers := make([]int32, numberOfSuperUnits)
+	for i := range markers {
+		markers[i] = -1
+	}
+
+	for i, members := range level.Members {
+		superUnit := &level.DataAbstractionSet.DataAbstractionUnits[i]
+		superUnit.SetDefaultValues()
+		superUnit.DataAbstractionUnitNumber = finerDataAbstractionUnits[members[0]].DataAbstractionUnitNumber
+		superUnit.Mass[0] = 0
+		for _, member := range members {
+			superUnit.Mass[0] += finerDataAbstractionUnits[member].Mass[0]
+		}
+
+		representative := superUnit.DataAbstractionUnitNumber
+		candidates := make([]DataAbstraction.Neighbour, 0)
+		markers[i] = int32(i)
+		for _, member := range members {
+			for _, neighbourIndex := range finerDataAbstractionUnits[member].NeighbourIndices[0] {
+				neighbourSuperUnitIndex := level.SuperUnitIndicesOfFinerLevel[neighbourIndex[0]]
+				if markers[neighbourSuperUnitIndex] != int32(i) {
+					markers[neighbourSuperUnitIndex] = int32(i)
+					neighbourRepresentative := finerDataAbstractionUnits[level.Members[neighbourSuperUnitIndex][0]].DataAbstractionUnitNumber
+					candidates = append(candidates, DataAbstraction.Neighbour{
+						DataAbstractionUnitIndex: neighbourSuperUnitIndex,
+						Distance:                 finerDataAbstractionSet.DistanceAfterTransformation(representative, neighbourRepresentative),
+					})
+				}
+			}
+		}
+
+		sort.Slice(candidates, func(a, b int) bool { return candidates[a].Distance < candidates[b].Distance })
+		if len(candidates) > numberOfNeighboursOfLevel {
+			candidates = candidates[:numberOfNeighboursOfLevel]
+		}
+
+		superUnit.NeighbourIndices = make([][][2]int32, 1)
+		superUnit.NeighbourIndices[0] = make([][2]int32, len(candidates))
+		for k, candidate := range candidates {
+			superUnit.NeighbourIndices[0][k][0] = candidate.DataAbstractionUnitIndex
+			superUnit.NeighbourIndices[0][k][1] = 0
+		}
+	}
+
+	return level
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) PerformMultilevelLayout() error {
+	multilevelConfiguration := dataEmbeddingTechniqueLVSDE.Multilevel
+	randomGenerator := rand.New(rand.NewSource(DeriveSeed(dataEmbeddingTechniqueLVSDE.RandomSeed, SeedStreamMultilevel)))
+
+	levels := make([]*MultilevelLevel, 0)
+	finerDataAbstractionSet := dataEmbeddingTechniqueLVSDE.DataAbstractionSet
+	numberOfNeighbours := dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph
+	numbersOfDataAbstractionUnitsOfLevels := []int32{int32(len(finerDataAbstractionSet.DataAbstractionUnits))}
+	for int32(len(finerDataAbstractionSet.DataAbstractionUnits)) > multilevelConfiguration.CoarsestLevelSize {
+		if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+			return ErrorHandling.NewCancellationError("coarsening the neighbourhood graph", 0, dataEmbeddingTechniqueLVSDE.Context.Err())
+		}
+
+		level := CoarsenDataAbstractionSet(finerDataAbstractionSet, numberOfNeighbours, randomGenerator)
+		numberOfSuperUnits := len(level.DataAbstractionSet.DataAbstractionUnits)
+		if float64(numberOfSuperUnits) > MaximumCoarseningRatio*float64(len(finerDataAbstractionSet.DataAbstractionUnits)) {
+			break
+		}
+
+		numberOfNeighbours = int32(math.Max(1, math.Round(float64(numberOfNeighbours)*float64(numberOfSuperUnits)/float64(len(finerDataAbstractionSet.DataAbstractionUnits)))))
+		levels = append(levels, level)
+		finerDataAbstractionSet = &level.DataAbstractionSet
+		numbersOfDataAbstractionUnitsOfLevels = append(numbersOfDataAbstractionUnitsOfLevels, int32(numberOfSuperUnits))
+	}
+
+	dataEmbeddingTechniqueLVSDE.EmbeddingDetails.MultilevelNumbersOfDataAbstractionUnits = numbersOfDataAbstractionUnitsOfLevels
+	Logging.Info("Multilevel coarsening finished.", Logging.Fields{
+		"number_of_levels": len(numbersOfDataAbstractionUnitsOfLevels),
+		"number_of_data_abstraction_units_of_coarsest_level": numbersOfDataAbstractionUnitsOfLevels[len(numbersOfDataAbstractionUnitsOfLevels)-1],
+	})
+
+	if len(levels) == 0 {
+		return nil
+	}
+
+	lastIterationOfPhaseOne := dataEmbeddingTechniqueLVSDE.PhaseSchedule.LastIterationOfPhase(1)
+	numberOfRefinementIterations := multilevelConfiguration.NumberOfRefinementIterations
+	if numberOfRefinementIterations > lastIterationOfPhaseOne {
+		numberOfRefinementIterations = lastIterationOfPhaseOne
+	}
+
+	coarsestDataAbstractionUnits := levels[len(levels)-1].DataAbstractionSet.DataAbstractionUnits
+	for i := range coarsestDataAbstractionUnits {
+		coarsestDataAbstractionUnits[i].VisualSpaceCoordinates[0][0] = randomGenerator.Float64() * dataEmbeddingTechniqueLVSDE.Width
+		coarsestDataAbstractionUnits[i].VisualSpaceCoordinates[0][1] = randomGenerator.Float64() * dataEmbeddingTechniqueLVSDE.Height
+	}
+
+	for levelNumber := len(levels) - 1; levelNumber >= 0; levelNumber-- {
+		firstIteration := lastIterationOfPhaseOne - numberOfRefinementIterations + 1
+		if levelNumber == len(levels)-1 {
+			firstIteration = 1
+		}
+
+		err := dataEmbeddingTechniqueLVSDE.LayOutMultilevelLevel(&levels[levelNumber].DataAbstractionSet, firstIteration, lastIterationOfPhaseOne)
+		if err != nil {
+			return err
+		}
+
+		Logging.Info("Multilevel level laid out.", Logging.Fields{
+			"level":                            levelNumber + 1,
+			"number_of_data_abstraction_units": len(levels[levelNumber].DataAbstractionSet.DataAbstractionUnits),
+			"number_of_iterations":             lastIterationOfPhaseOne - firstIteration + 1,
+		})
+
+		finerDataAbstractionSet := dataEmbeddingTechniqueLVSDE.DataAbstractionSet
+		if levelNumber > 0 {
+			finerDataAbstractionSet = &levels[levelNumber-1].DataAbstractionSet
+		}
+		dataEmbeddingTechniqueLVSDE.ProlongMultilevelLevel(levels[levelNumber], finerDataAbstractionSet, randomGenerator)
+	}
+
+	dataEmbeddingTechniqueLVSDE.IterationOffset = lastIterationOfPhaseOne - numberOfRefinementIterations
+
+	return nil
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) LayOutMultilevelLevel(dataAbstractionSet *DataAbstraction.DataAbstractionSet, firstIteration int32, lastIteration int32) error {
+	numberOfDataAbstractionUnits := float64(len(dataAbstractionSet.DataAbstractionUnits))
+
+	var levelTechnique DataEmbeddingTechniqueLVSDE
+	levelTechnique.Context = dataEmbeddingTechniqueLVSDE.Context
+	levelTechnique.VisualDensityAdjustmentParameter = dataEmbeddingTechniqueLVSDE.VisualDensityAdjustmentParameter
+	levelTechnique.NumberOfParallelSlices = dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices
+	levelTechnique.DataAbstractionSet = dataAbstractionSet
+	levelTechnique.CurrentPhase = 1
+	levelTechnique.Epsilon = dataEmbeddingTechniqueLVSDE.Epsilon
+	levelTechnique.SquaredBaseDistance = (dataEmbeddingTechniqueLVSDE.Width * dataEmbeddingTechniqueLVSDE.Height) / numberOfDataAbstractionUnits
+	levelTechnique.BaseDistance = math.Sqrt(levelTechnique.SquaredBaseDistance)
+	levelTechnique.OriginalSpaceMaximumTransformedDistance = dataEmbeddingTechniqueLVSDE.OriginalSpaceMaximumTransformedDistance
+	levelTechnique.VisualSpaceMaximumDistanceFirstIteration = dataEmbeddingTechniqueLVSDE.VisualSpaceMaximumDistanceFirstIteration
+	levelTechnique.RepulsiveForces = dataEmbeddingTechniqueLVSDE.RepulsiveForces
+
+	var meanDisplacement float64 = 0
+	for levelTechnique.Iteration = firstIteration; levelTechnique.Iteration <= lastIteration; levelTechnique.Iteration++ {
+		levelTechnique.Temperature = dataEmbeddingTechniqueLVSDE.CoolingSchedule.Temperature(CoolingState{
+			Iteration:                           levelTechnique.Iteration,
+			Phase:                               1,
+			InitialTemperature:                  dataEmbeddingTechniqueLVSDE.InitialTemperature,
+			PhaseSchedule:                       &dataEmbeddingTechniqueLVSDE.PhaseSchedule,
+			MeanDisplacementOfPreviousIteration: meanDisplacement,
+			FirstIterationOfRun:                 firstIteration,
+		})
+
+		var err error
+		meanDisplacement, err = levelTechnique.CalculateForcesAndMoveVisualSpaceProjections()
+		if err != nil {
+			if dataEmbeddingTechniqueLVSDE.Context.Err() != nil {
+				return ErrorHandling.NewCancellationError("running the multilevel layout", 0, dataEmbeddingTechniqueLVSDE.Context.Err())
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) ProlongMultilevelLevel(level *MultilevelLevel, finerDataAbstractionSet *DataAbstraction.DataAbstractionSet, randomGenerator *rand.Rand) {
+	finerBaseDistance := math.Sqrt((dataEmbeddingTechniqueLVSDE.Width * dataEmbeddingTechniqueLVSDE.Height) / float64(len(finerDataAbstractionSet.DataAbstractionUnits)))
+
+	for i, members := range level.Members {
+		superUnitVisualSpaceCoordinates := level.DataAbstractionSet.DataAbstractionUnits[i].VisualSpaceCoordinates[0]
+		for k, member := range members {
+			finerDataAbstractionUnit := &finerDataAbstractionSet.DataAbstractionUnits[member]
+			finerDataAbstractionUnit.VisualSpaceCoordinates[0] = superUnitVisualSpaceCoordinates
+			finerDataAbstractionUnit.TemporaryVisualSpaceCoordinates[0] = [2]float64{0, 0}
+			if k == 0 {
+				continue
+			}
+
+			angle := randomGenerator.Float64() * 2 * math.Pi
+			radius := randomGenerator.Float64() * finerBaseDistance * 0.5
+			finerDataAbstractionUnit.VisualSpaceCoordinates[0][0] += radius * math.Cos(angle)
+			finerDataAbstractionUnit.VisualSpaceCoordinates[0][1] += radius * math.Sin(angle)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"sort"
+)
+
+const (
+	NearestNeighbourMethodExact     = "exact"
+	NearestNeighbourMethodNNDescent = "nn_descent"
+)
+
+var NearestNeighbourMethods = []string{NearestNeighbourMethodExact, NearestNeighbourMethodNNDescent}
+
+const (
+	DistanceStorageDense  = "dense"
+	DistanceStorageSparse = "sparse"
+)
+
+var DistanceStorages = []string{DistanceStorageDense, DistanceStorageSparse}
+
+const NumberOfSampledPairsPerDataAbstractionUnitForMaximumDistanceEstimation int32 = 20
+
+const DefaultMaximumNumberOfNNDescentIterations int32 = 12
+
+const DefaultNNDescentTerminationFraction float64 = 0.001
+
+const NumberOfCandidatesPerNeighbourhoodGraphNeighbour int32 = 2
+
+type NearestNeighbourConfiguration struct {
+	Method                    string  `json:"method"`
+	MaximumNumberOfIterations int32   `json:"maximum_number_of_iterations"`
+	TerminationFraction       float64 `json:"termination_fraction"`
+}
+
+func DefaultNearestNeighbourConfiguration() NearestNeighbourConfiguration {
+	return NearestNeighbourConfiguration{
+		Method:                    NearestNeighbourMethodExact,
+		MaximumNumberOfIterations: DefaultMaximumNumberOfNNDescentIterations,
+		TerminationFraction:       DefaultNNDescentTerminationFraction,
+	}
+}
+
+func NewNearestNeighbourSearch(nearestNeighbourConfiguration NearestNeighbourConfiguration, randomSeed int64, numberOfParallelSlices int32) DataAbstraction.NearestNeighbourSearch {
+	if nearestNeighbourConfiguration.Method == NearestNeighbourMethodNNDescent {
+		nnDescentNearestNeighbourSearch := DataAbstraction.NNDescentNearestNeighbourSearch{
+			NumberOfParallelSlices:    numberOfParallelSlices,
+			MaximumNumberOfIterations: nearestNeighbourConfiguration.MaximumNumberOfIterations,
+			TerminationFraction:       nearestNeighbourConfiguration.TerminationFraction,
+			RandomSeed:                DeriveSeed(randomSeed, SeedStreamNearestNeighbours),
+		}
+		if nnDescentNearestNeighbourSearch.MaximumNumberOfIterations <= 0 {
+			nnDescentNearestNeighbourSearch.MaximumNumberOfIterations = DefaultMaximumNumberOfNNDescentIterations
+		}
+		return nnDescentNearestNeighbourSearch
+	}
+	return DataAbstraction.ExactNearestNeighbourSearch{NumberOfParallelSlices: numberOfParallelSlices}
+}
+
+// BuildNeighbourhoodGraph finds the neighbours of every data abstraction unit by distance after transformation.
+// The approximate methods only rank a few times more candidates, found by distance before transformation, than the
+// number of neighbours. With the sparse distance storage, only the distances after transformation to the neighbours
+// are kept. The exact method then still compares every pair of data abstraction units, and as no neighbours are kept
+// yet, recomputes each of these distances from the coordinates, so its time grows quadratically with the number of
+// data abstraction units even though its memory does not.
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) BuildNeighbourhoodGraph() error {
+	dataAbstractionSet := dataEmbeddingTechniqueLVSDE.DataAbstractionSet
+	numberOfDataAbstractionUnits := int32(len(dataAbstractionSet.DataAbstractionUnits))
+	numberOfNeighbours := dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph
+	if numberOfNeighbours > numberOfDataAbstractionUnits-1 {
+		return fmt.Errorf("%w: %d neighbours are needed for building the neighbourhood graph but there are only %d other data abstraction units", ErrorHandling.ErrNotEnoughDataAbstractionUnits, numberOfNeighbours, numberOfDataAbstractionUnits-1)
+	}
+
+	nearestNeighbourSearch := NewNearestNeighbourSearch(dataEmbeddingTechniqueLVSDE.NearestNeighbours, dataEmbeddingTechniqueLVSDE.RandomSeed, dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices)
+
+	var candidates [][]DataAbstraction.Neighbour
+	var err error
+	if dataEmbeddingTechniqueLVSDE.NearestNeighbours.Method == NearestNeighbourMethodNNDescent {
+		numberOfCandidates := numberOfNeighbours * NumberOfCandidatesPerNeighbourhoodGraphNeighbour
+		if numberOfCandidates < DataAbstraction.NumberOfNeighboursForDistanceTransformation {
+			numberOfCandidates = DataAbstraction.NumberOfNeighboursForDistanceTransformation
+		}
+		if numberOfCandidates > numberOfDataAbstractionUnits-1 {
+			numberOfCandidates = numberOfDataAbstractionUnits - 1
+		}
+
+		candidates, err = nearestNeighbourSearch.FindNearestNeighbours(dataEmbeddingTechniqueLVSDE.Context, dataAbstractionSet, numberOfCandidates)
+		if err != nil {
+			return err
+		}
+	}
+
+	isSparse := dataEmbeddingTechniqueLVSDE.DistanceStorage == DistanceStorageSparse && dataAbstractionSet.DistancesAfterTransformation == nil
+
+	if !dataAbstractionSet.HasDistancesAfterTransformation() {
+		if isSparse {
+			nearestNeighbours := candidates
+			if nearestNeighbours == nil {
+				nearestNeighbours, err = nearestNeighbourSearch.FindNearestNeighbours(dataEmbeddingTechniqueLVSDE.Context, dataAbstractionSet, DataAbstraction.NumberOfNeighboursForDistanceTransformation)
+				if err != nil {
+					return err
+				}
+			}
+			err = dataAbstractionSet.ComputeSparseDistancesAfterTransformationFromNearestNeighbours(nearestNeighbours)
+		} else if candidates != nil {
+			err = dataAbstractionSet.ComputeDistancesAfterTransformationFromNearestNeighbours(dataEmbeddingTechniqueLVSDE.Context, candidates)
+		} else {
+			err = dataAbstractionSet.ComputeDistancesAfterTransformation(dataEmbeddingTechniqueLVSDE.Context, nearestNeighbourSearch)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	var neighbours [][]DataAbstraction.Neighbour
+	if candidates == nil {
+		neighbours, err = DataAbstraction.FindExactNearestNeighbours(dataEmbeddingTechniqueLVSDE.Context, numberOfDataAbstractionUnits, dataAbstractionSet.DistanceAfterTransformation, numberOfNeighbours, dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices)
+		if err != nil {
+			return err
+		}
+	} else {
+		neighbours = candidates
+		for i := range neighbours {
+			for k := range neighbours[i] {
+				neighbours[i][k].Distance = dataAbstractionSet.DistanceAfterTransformation(int32(i), neighbours[i][k].DataAbstractionUnitIndex)
+			}
+			sort.SliceStable(neighbours[i], func(a, b int) bool { return neighbours[i][a].Distance < neighbours[i][b].Distance })
+			neighbours[i] = neighbours[i][:numberOfNeighbours]
+		}
+	}
+
+	if isSparse {
+		for i := range neighbours {
+			dataAbstractionSet.SparseDistancesAfterTransformation.SetNeighbours(int32(i), neighbours[i])
+		}
+	}
+
+	for i := range dataAbstractionSet.DataAbstractionUnits {
+		dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[i]
+		dataAbstractionUnit.NeighbourIndices = make([][][2]int32, 1)
+		dataAbstractionUnit.NeighbourIndices[0] = make([][2]int32, numberOfNeighbours)
+		for j, neighbour := range neighbours[i] {
+			dataAbstractionUnit.NeighbourIndices[0][j][0] = neighbour.DataAbstractionUnitIndex
+			dataAbstractionUnit.NeighbourIndices[0][j][1] = 0
+		}
+	}
+
+	return nil
+}
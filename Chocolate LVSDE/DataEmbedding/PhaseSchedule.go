@@ -0,0 +1,89 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"math"
+)
+
+const NumberOfPhases = 4
+
+var DefaultNumbersOfIterationsOfPhases = [NumberOfPhases]int32{500, 450, 390, 490}
+
+const defaultTemperatureDecayIterations float64 = 1000.0
+
+const defaultTemperatureRestartIterations float64 = 510.0
+
+type PhaseSchedule struct {
+	NumbersOfIterationsOfPhases  [NumberOfPhases]int32 `json:"numbers_of_iterations_of_phases"`
+	TemperatureDecayIterations   float64               `json:"temperature_decay_iterations"`
+	TemperatureRestartIterations int32                 `json:"temperature_restart_iterations"`
+}
+
+func DefaultPhaseSchedule() PhaseSchedule {
+	return NewPhaseSchedule(DefaultNumbersOfIterationsOfPhases)
+}
+
+func ScaledPhaseSchedule(scaleFactor float64) PhaseSchedule {
+	var numbersOfIterationsOfPhases [NumberOfPhases]int32
+	for i := range numbersOfIterationsOfPhases {
+		numbersOfIterationsOfPhases[i] = int32(math.Max(1, math.Round(float64(DefaultNumbersOfIterationsOfPhases[i])*scaleFactor)))
+	}
+	return NewPhaseSchedule(numbersOfIterationsOfPhases)
+}
+
+func NewPhaseSchedule(numbersOfIterationsOfPhases [NumberOfPhases]int32) PhaseSchedule {
+	phaseSchedule := PhaseSchedule{NumbersOfIterationsOfPhases: numbersOfIterationsOfPhases}
+
+	scaleFactor := float64(phaseSchedule.NumberOfIterations()) / float64(DefaultPhaseScheduleNumberOfIterations())
+	phaseSchedule.TemperatureDecayIterations = defaultTemperatureDecayIterations * scaleFactor
+	phaseSchedule.TemperatureRestartIterations = int32(math.Round(defaultTemperatureRestartIterations * scaleFactor))
+
+	return phaseSchedule
+}
+
+func DefaultPhaseScheduleNumberOfIterations() int32 {
+	var numberOfIterations int32 = 0
+	for _, numberOfIterationsOfPhase := range DefaultNumbersOfIterationsOfPhases {
+		numberOfIterations += numberOfIterationsOfPhase
+	}
+	return numberOfIterations
+}
+
+func (phaseSchedule *PhaseSchedule) IsSet() bool {
+	return phaseSchedule.NumberOfIterations() > 0
+}
+
+func (phaseSchedule *PhaseSchedule) NumberOfIterations() int32 {
+	return phaseSchedule.LastIterationOfPhase(NumberOfPhases)
+}
+
+func (phaseSchedule *PhaseSchedule) LastIterationOfPhase(phase int32) int32 {
+	var lastIteration int32 = 0
+	for i := int32(0); i < phase && i < NumberOfPhases; i++ {
+		lastIteration += phaseSchedule.NumbersOfIterationsOfPhases[i]
+	}
+	return lastIteration
+}
+
+func (phaseSchedule *PhaseSchedule) TemperatureAdjustmentOfPhase(phase int32) int32 {
+	if phase <= 2 {
+		return -1
+	}
+	return phaseSchedule.LastIterationOfPhase(phase-1) - phaseSchedule.TemperatureRestartIterations
+}
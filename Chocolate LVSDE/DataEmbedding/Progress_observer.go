@@ -0,0 +1,164 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
+)
+
+type IterationCompletedEvent struct {
+	Iteration          int32
+	NumberOfIterations int32
+	Temperature        float64
+	Phase              int32
+	MeanDisplacement   float64
+}
+
+type PhaseChangedEvent struct {
+	Iteration                            int32
+	OldPhase                             int32
+	NewPhase                             int32
+	FrameLowX                            float64
+	FrameHighX                           float64
+	FrameLowY                            float64
+	FrameHighY                           float64
+	GrayLayerDataAbstractionUnitCapacity int32
+	GrayLayerDataAbstractionUnitSize     int32
+}
+
+type DataAbstractionUnitMovedToGrayLayerEvent struct {
+	Iteration                            int32
+	DataAbstractionUnitNumber            int32
+	MaximumPressure                      float64
+	GrayLayerDataAbstractionUnitCapacity int32
+	GrayLayerDataAbstractionUnitSize     int32
+}
+
+type VertexSplitEvent struct {
+	Iteration                 int32
+	DataAbstractionUnitNumber int32
+	HasVertexSplitFailed      bool
+	SelectedAxis              int
+	NumberOfNeighbours1       int
+	NumberOfNeighbours2       int
+}
+
+type ProgressObserver interface {
+	IterationCompleted(event IterationCompletedEvent)
+	PhaseChanged(event PhaseChangedEvent)
+	DataAbstractionUnitMovedToGrayLayer(event DataAbstractionUnitMovedToGrayLayerEvent)
+	VertexSplit(event VertexSplitEvent)
+}
+
+type LoggingProgressObserver struct {
+}
+
+func (loggingProgressObserver LoggingProgressObserver) IterationCompleted(event IterationCompletedEvent) {
+	level := Logging.LevelDebug
+	if event.Iteration%300 == 0 || event.Iteration == 1 || event.Iteration == event.NumberOfIterations {
+		level = Logging.LevelInfo
+	}
+
+	Logging.Log(level, "LVSDE iteration finished.", Logging.Fields{
+		"iteration":         event.Iteration,
+		"phase":             event.Phase,
+		"temperature":       event.Temperature,
+		"mean_displacement": event.MeanDisplacement,
+	})
+}
+
+func (loggingProgressObserver LoggingProgressObserver) PhaseChanged(event PhaseChangedEvent) {
+	if event.NewPhase == 1 {
+		Logging.Info("LVSDE phase 1 started.", nil)
+		return
+	}
+
+	fields := Logging.Fields{
+		"iteration":    event.Iteration,
+		"old_phase":    event.OldPhase,
+		"new_phase":    event.NewPhase,
+		"frame_low_x":  event.FrameLowX,
+		"frame_high_x": event.FrameHighX,
+		"frame_low_y":  event.FrameLowY,
+		"frame_high_y": event.FrameHighY,
+	}
+	if event.GrayLayerDataAbstractionUnitCapacity >= 0 {
+		fields["gray_layer_capacity"] = event.GrayLayerDataAbstractionUnitCapacity
+		fields["gray_layer_size"] = event.GrayLayerDataAbstractionUnitSize
+	}
+	Logging.Info(fmt.Sprintf("LVSDE phase %d started.", int(event.NewPhase)), fields)
+}
+
+func (loggingProgressObserver LoggingProgressObserver) DataAbstractionUnitMovedToGrayLayer(event DataAbstractionUnitMovedToGrayLayerEvent) {
+	Logging.Debug("Data abstraction unit moved to gray layer.", Logging.Fields{
+		"iteration":                    event.Iteration,
+		"data_abstraction_unit_number": event.DataAbstractionUnitNumber,
+		"maximum_pressure":             event.MaximumPressure,
+		"gray_layer_capacity":          event.GrayLayerDataAbstractionUnitCapacity,
+		"gray_layer_size":              event.GrayLayerDataAbstractionUnitSize,
+	})
+}
+
+func (loggingProgressObserver LoggingProgressObserver) VertexSplit(event VertexSplitEvent) {
+	Logging.Debug("Vertex split attempted.", Logging.Fields{
+		"iteration":                    event.Iteration,
+		"data_abstraction_unit_number": event.DataAbstractionUnitNumber,
+		"has_vertex_split_failed":      event.HasVertexSplitFailed,
+		"selected_axis":                event.SelectedAxis,
+		"number_of_neighbours_1":       event.NumberOfNeighbours1,
+		"number_of_neighbours_2":       event.NumberOfNeighbours2,
+	})
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) AddProgressObserver(progressObserver ProgressObserver) {
+	dataEmbeddingTechniqueLVSDE.ProgressObservers = append(dataEmbeddingTechniqueLVSDE.ProgressObservers, progressObserver)
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) notifyPhaseChanged(oldPhase int32) {
+	event := PhaseChangedEvent{
+		Iteration:                            dataEmbeddingTechniqueLVSDE.Iteration,
+		OldPhase:                             oldPhase,
+		NewPhase:                             dataEmbeddingTechniqueLVSDE.CurrentPhase,
+		FrameLowX:                            dataEmbeddingTechniqueLVSDE.FrameLowX,
+		FrameHighX:                           dataEmbeddingTechniqueLVSDE.FrameHighX,
+		FrameLowY:                            dataEmbeddingTechniqueLVSDE.FrameLowY,
+		FrameHighY:                           dataEmbeddingTechniqueLVSDE.FrameHighY,
+		GrayLayerDataAbstractionUnitCapacity: dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitCapacity,
+		GrayLayerDataAbstractionUnitSize:     dataEmbeddingTechniqueLVSDE.GrayLayerDataAbstractionUnitSize,
+	}
+
+	for _, progressObserver := range dataEmbeddingTechniqueLVSDE.ProgressObservers {
+		progressObserver.PhaseChanged(event)
+	}
+}
+
+func (dataEmbeddingTechniqueLVSDE *DataEmbeddingTechniqueLVSDE) notifyVertexSplit(dataAbstractionUnitNumber int32, hasVertexSplitFailed bool, selectedAxis int, numberOfNeighbours1 int, numberOfNeighbours2 int) {
+	event := VertexSplitEvent{
+		Iteration:                 dataEmbeddingTechniqueLVSDE.Iteration,
+		DataAbstractionUnitNumber: dataAbstractionUnitNumber,
+		HasVertexSplitFailed:      hasVertexSplitFailed,
+		SelectedAxis:              selectedAxis,
+		NumberOfNeighbours1:       numberOfNeighbours1,
+		NumberOfNeighbours2:       numberOfNeighbours2,
+	}
+
+	for _, progressObserver := range dataEmbeddingTechniqueLVSDE.ProgressObservers {
+		progressObserver.VertexSplit(event)
+	}
+}
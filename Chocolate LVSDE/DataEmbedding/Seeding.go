@@ -0,0 +1,108 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+)
+
+const DefaultShufflingRandomSeed int64 = 849662123548415231
+
+const (
+	SeedStreamLVSDEInitialisation   = "lvsde_initialisation"
+	SeedStreamUMAP                  = "umap"
+	SeedStreamTSNE                  = "tsne"
+	SeedStreamRenderShuffling       = "render_shuffling"
+	SeedStreamEvaluationTieBreaking = "evaluation_tie_breaking"
+	SeedStreamSubsampling           = "subsampling"
+	SeedStreamMultilevel            = "multilevel"
+	SeedStreamNearestNeighbours     = "nearest_neighbours"
+	SeedStreamDistanceEstimation    = "distance_estimation"
+)
+
+const maximumPythonRandomState int64 = 2147483647
+
+type Seeds struct {
+	MasterSeed            *int64 `json:"master_seed"`
+	LVSDEInitialisation   int64  `json:"lvsde_initialisation"`
+	UMAP                  int64  `json:"umap"`
+	TSNE                  int64  `json:"tsne"`
+	RenderShuffling       int64  `json:"render_shuffling"`
+	EvaluationTieBreaking int64  `json:"evaluation_tie_breaking"`
+	Subsampling           *int64 `json:"subsampling"`
+}
+
+func DeriveSeed(masterSeed int64, seedStreamName string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(seedStreamName))
+
+	state := uint64(masterSeed) ^ hash.Sum64()
+	state += 0x9e3779b97f4a7c15
+	state = (state ^ (state >> 30)) * 0xbf58476d1ce4e5b9
+	state = (state ^ (state >> 27)) * 0x94d049bb133111eb
+	state = state ^ (state >> 31)
+
+	return int64(state >> 1)
+}
+
+func DerivePythonRandomState(masterSeed int64, seedStreamName string) int64 {
+	return DeriveSeed(masterSeed, seedStreamName) % (maximumPythonRandomState + 1)
+}
+
+func DeriveSeedsFromMasterSeed(masterSeed int64) Seeds {
+	subsamplingSeed := DeriveSeed(masterSeed, SeedStreamSubsampling)
+	return Seeds{
+		MasterSeed:            &masterSeed,
+		LVSDEInitialisation:   DeriveSeed(masterSeed, SeedStreamLVSDEInitialisation),
+		UMAP:                  DerivePythonRandomState(masterSeed, SeedStreamUMAP),
+		TSNE:                  DerivePythonRandomState(masterSeed, SeedStreamTSNE),
+		RenderShuffling:       DeriveSeed(masterSeed, SeedStreamRenderShuffling),
+		EvaluationTieBreaking: DeriveSeed(masterSeed, SeedStreamEvaluationTieBreaking),
+		Subsampling:           &subsamplingSeed,
+	}
+}
+
+func LegacySeeds(randomSeed int64, randomState int64) Seeds {
+	return Seeds{
+		LVSDEInitialisation:   randomSeed,
+		UMAP:                  randomState,
+		TSNE:                  randomState,
+		RenderShuffling:       DefaultShufflingRandomSeed,
+		EvaluationTieBreaking: DefaultShufflingRandomSeed,
+	}
+}
+
+func RandomSeedFromRandomState(randomState int64) int64 {
+	randomSeed := DefaultRandomSeed
+	randomGenerator := rand.New(rand.NewSource(randomSeed))
+	for i := int64(0); i < randomState; i++ {
+		randomSeed = randomGenerator.Int63()
+	}
+	return randomSeed
+}
+
+func ShufflingSeedsOfEmbeddingDetails(embeddingDetails *DataAbstraction.EmbeddingDetails) (renderShufflingSeed int64, evaluationTieBreakingSeed int64) {
+	masterSeed, err := strconv.ParseInt(embeddingDetails.MasterSeed, 10, 64)
+	if err != nil {
+		return DefaultShufflingRandomSeed, DefaultShufflingRandomSeed
+	}
+	return DeriveSeed(masterSeed, SeedStreamRenderShuffling), DeriveSeed(masterSeed, SeedStreamEvaluationTieBreaking)
+}
@@ -0,0 +1,223 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package DataEmbedding
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"math"
+	"sort"
+)
+
+type LayoutStability struct {
+	DataAbstractionUnitNumbers                       []int32        `json:"-"`
+	ClassLabelNumbers                                []int32        `json:"-"`
+	AlignedPositions                                 [][][2]float64 `json:"-"`
+	MeanPositions                                    [][2]float64   `json:"-"`
+	PositionalVariances                              []float64      `json:"-"`
+	GrayLayerFrequencies                             []float64      `json:"-"`
+	NumberOfLayouts                                  int            `json:"number_of_layouts"`
+	NumberOfDataAbstractionUnits                     int            `json:"number_of_data_abstraction_units"`
+	MeanPositionalVariance                           float64        `json:"mean_positional_variance"`
+	MedianPositionalVariance                         float64        `json:"median_positional_variance"`
+	MaximumPositionalVariance                        float64        `json:"maximum_positional_variance"`
+	ProcrustesDisparities                            [][]float64    `json:"procrustes_disparities"`
+	MeanProcrustesDisparity                          float64        `json:"mean_procrustes_disparity"`
+	FractionOfDataAbstractionUnitsWithAgreeingLayers float64        `json:"fraction_of_data_abstraction_units_with_agreeing_layers"`
+	NumberOfDataAbstractionUnitsAlwaysInGrayLayer    int            `json:"number_of_data_abstraction_units_always_in_gray_layer"`
+	NumberOfDataAbstractionUnitsSometimesInGrayLayer int            `json:"number_of_data_abstraction_units_sometimes_in_gray_layer"`
+}
+
+func AnalyseLayoutStability(layouts [][]*DataAbstraction.DataAbstractionUnitVisibility) (*LayoutStability, error) {
+	if len(layouts) < 2 {
+		return nil, fmt.Errorf("%w: at least 2 layouts are needed for a stability analysis but %d given", ErrorHandling.ErrInvalidInput, len(layouts))
+	}
+
+	layoutStability := new(LayoutStability)
+	layoutStability.NumberOfLayouts = len(layouts)
+	layoutStability.NumberOfDataAbstractionUnits = len(layouts[0])
+
+	referenceLayout := make([]*DataAbstraction.DataAbstractionUnitVisibility, len(layouts[0]))
+	copy(referenceLayout, layouts[0])
+	sort.Slice(referenceLayout, func(i, j int) bool {
+		return referenceLayout[i].DataAbstractionUnitNumber < referenceLayout[j].DataAbstractionUnitNumber
+	})
+
+	indicesByDataAbstractionUnitNumber := make(map[int32]int)
+	layoutStability.DataAbstractionUnitNumbers = make([]int32, len(referenceLayout))
+	layoutStability.ClassLabelNumbers = make([]int32, len(referenceLayout))
+	for i, dataAbstractionUnitVisibility := range referenceLayout {
+		indicesByDataAbstractionUnitNumber[dataAbstractionUnitVisibility.DataAbstractionUnitNumber] = i
+		layoutStability.DataAbstractionUnitNumbers[i] = dataAbstractionUnitVisibility.DataAbstractionUnitNumber
+		layoutStability.ClassLabelNumbers[i] = dataAbstractionUnitVisibility.ClassLabelNumber
+	}
+
+	positions := make([][][2]float64, len(layouts))
+	isInGrayLayer := make([][]bool, len(layouts))
+	for l, layout := range layouts {
+		if len(layout) != len(referenceLayout) {
+			return nil, fmt.Errorf("%w: layout %d has %d data abstraction units but layout 0 has %d", ErrorHandling.ErrInvalidInput, l, len(layout), len(referenceLayout))
+		}
+
+		positions[l] = make([][2]float64, len(referenceLayout))
+		isInGrayLayer[l] = make([]bool, len(referenceLayout))
+		for _, dataAbstractionUnitVisibility := range layout {
+			i, exists := indicesByDataAbstractionUnitNumber[dataAbstractionUnitVisibility.DataAbstractionUnitNumber]
+			if !exists || len(dataAbstractionUnitVisibility.VisualSpaceCoordinates) == 0 {
+				return nil, fmt.Errorf("%w: data abstraction unit %d of layout %d has no matching position in layout 0", ErrorHandling.ErrInvalidInput, dataAbstractionUnitVisibility.DataAbstractionUnitNumber, l)
+			}
+
+			for _, visualSpaceCoordinates := range dataAbstractionUnitVisibility.VisualSpaceCoordinates {
+				positions[l][i][0] += visualSpaceCoordinates[0] / float64(len(dataAbstractionUnitVisibility.VisualSpaceCoordinates))
+				positions[l][i][1] += visualSpaceCoordinates[1] / float64(len(dataAbstractionUnitVisibility.VisualSpaceCoordinates))
+			}
+			isInGrayLayer[l][i] = dataAbstractionUnitVisibility.Layer == "gray"
+		}
+	}
+
+	layoutStability.AlignedPositions = make([][][2]float64, len(layouts))
+	layoutStability.AlignedPositions[0] = positions[0]
+	for l := 1; l < len(layouts); l++ {
+		layoutStability.AlignedPositions[l], _ = AlignByProcrustes(positions[0], positions[l])
+	}
+
+	layoutStability.ProcrustesDisparities = make([][]float64, len(layouts))
+	numberOfPairs := 0
+	for l1 := range layouts {
+		layoutStability.ProcrustesDisparities[l1] = make([]float64, len(layouts))
+		for l2 := 0; l2 < l1; l2++ {
+			_, disparity := AlignByProcrustes(positions[l2], positions[l1])
+			layoutStability.ProcrustesDisparities[l1][l2] = disparity
+			layoutStability.ProcrustesDisparities[l2][l1] = disparity
+			layoutStability.MeanProcrustesDisparity += disparity
+			numberOfPairs++
+		}
+	}
+	layoutStability.MeanProcrustesDisparity /= float64(numberOfPairs)
+
+	numberOfDataAbstractionUnits := len(referenceLayout)
+	layoutStability.MeanPositions = make([][2]float64, numberOfDataAbstractionUnits)
+	layoutStability.PositionalVariances = make([]float64, numberOfDataAbstractionUnits)
+	layoutStability.GrayLayerFrequencies = make([]float64, numberOfDataAbstractionUnits)
+	numberOfDataAbstractionUnitsWithAgreeingLayers := 0
+
+	for i := 0; i < numberOfDataAbstractionUnits; i++ {
+		numberOfTimesInGrayLayer := 0
+		for l := range layouts {
+			layoutStability.MeanPositions[i][0] += layoutStability.AlignedPositions[l][i][0] / float64(len(layouts))
+			layoutStability.MeanPositions[i][1] += layoutStability.AlignedPositions[l][i][1] / float64(len(layouts))
+			if isInGrayLayer[l][i] {
+				numberOfTimesInGrayLayer++
+			}
+		}
+
+		for l := range layouts {
+			horizontalDifference := layoutStability.AlignedPositions[l][i][0] - layoutStability.MeanPositions[i][0]
+			verticalDifference := layoutStability.AlignedPositions[l][i][1] - layoutStability.MeanPositions[i][1]
+			layoutStability.PositionalVariances[i] += (horizontalDifference*horizontalDifference + verticalDifference*verticalDifference) / float64(len(layouts))
+		}
+
+		layoutStability.GrayLayerFrequencies[i] = float64(numberOfTimesInGrayLayer) / float64(len(layouts))
+		if numberOfTimesInGrayLayer == 0 || numberOfTimesInGrayLayer == len(layouts) {
+			numberOfDataAbstractionUnitsWithAgreeingLayers++
+		}
+		if numberOfTimesInGrayLayer == len(layouts) {
+			layoutStability.NumberOfDataAbstractionUnitsAlwaysInGrayLayer++
+		} else if numberOfTimesInGrayLayer > 0 {
+			layoutStability.NumberOfDataAbstractionUnitsSometimesInGrayLayer++
+		}
+
+		layoutStability.MeanPositionalVariance += layoutStability.PositionalVariances[i] / float64(numberOfDataAbstractionUnits)
+		layoutStability.MaximumPositionalVariance = math.Max(layoutStability.MaximumPositionalVariance, layoutStability.PositionalVariances[i])
+	}
+
+	sortedPositionalVariances := make([]float64, numberOfDataAbstractionUnits)
+	copy(sortedPositionalVariances, layoutStability.PositionalVariances)
+	sort.Float64s(sortedPositionalVariances)
+	if numberOfDataAbstractionUnits%2 == 1 {
+		layoutStability.MedianPositionalVariance = sortedPositionalVariances[numberOfDataAbstractionUnits/2]
+	} else if numberOfDataAbstractionUnits > 0 {
+		layoutStability.MedianPositionalVariance = (sortedPositionalVariances[numberOfDataAbstractionUnits/2-1] + sortedPositionalVariances[numberOfDataAbstractionUnits/2]) / 2
+	}
+
+	layoutStability.FractionOfDataAbstractionUnitsWithAgreeingLayers = float64(numberOfDataAbstractionUnitsWithAgreeingLayers) / float64(numberOfDataAbstractionUnits)
+
+	return layoutStability, nil
+}
+
+func AlignByProcrustes(referencePositions [][2]float64, positions [][2]float64) ([][2]float64, float64) {
+	numberOfPositions := len(referencePositions)
+	var referenceCentroid, centroid [2]float64
+	for i := 0; i < numberOfPositions; i++ {
+		referenceCentroid[0] += referencePositions[i][0] / float64(numberOfPositions)
+		referenceCentroid[1] += referencePositions[i][1] / float64(numberOfPositions)
+		centroid[0] += positions[i][0] / float64(numberOfPositions)
+		centroid[1] += positions[i][1] / float64(numberOfPositions)
+	}
+
+	var referenceSumOfSquares, sumOfSquares float64
+	var a, b, aReflected, bReflected float64
+	for i := 0; i < numberOfPositions; i++ {
+		x1 := referencePositions[i][0] - referenceCentroid[0]
+		x2 := referencePositions[i][1] - referenceCentroid[1]
+		y1 := positions[i][0] - centroid[0]
+		y2 := positions[i][1] - centroid[1]
+
+		referenceSumOfSquares += x1*x1 + x2*x2
+		sumOfSquares += y1*y1 + y2*y2
+
+		a += x1*y1 + x2*y2
+		b += x2*y1 - x1*y2
+		aReflected += x1*y1 - x2*y2
+		bReflected += x2*y1 + x1*y2
+	}
+
+	alignedPositions := make([][2]float64, numberOfPositions)
+	if referenceSumOfSquares == 0 || sumOfSquares == 0 {
+		for i := range alignedPositions {
+			alignedPositions[i] = referenceCentroid
+		}
+		return alignedPositions, 1
+	}
+
+	isReflected := false
+	correlation := math.Sqrt(a*a + b*b)
+	if reflectedCorrelation := math.Sqrt(aReflected*aReflected + bReflected*bReflected); reflectedCorrelation > correlation {
+		isReflected = true
+		correlation = reflectedCorrelation
+		a, b = aReflected, bReflected
+	}
+
+	angle := math.Atan2(b, a)
+	cosine, sine := math.Cos(angle), math.Sin(angle)
+	scale := correlation / sumOfSquares
+
+	for i := 0; i < numberOfPositions; i++ {
+		y1 := positions[i][0] - centroid[0]
+		y2 := positions[i][1] - centroid[1]
+		if isReflected {
+			y2 = -y2
+		}
+		alignedPositions[i][0] = scale*(cosine*y1-sine*y2) + referenceCentroid[0]
+		alignedPositions[i][1] = scale*(sine*y1+cosine*y2) + referenceCentroid[1]
+	}
+
+	disparity := 1 - correlation*correlation/(referenceSumOfSquares*sumOfSquares)
+	return alignedPositions, math.Max(disparity, 0)
+}
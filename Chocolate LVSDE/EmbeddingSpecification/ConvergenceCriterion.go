@@ -0,0 +1,41 @@
+/*
+Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+Copyright notice for this code (this implementation of LVSDE) and this file:
+Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+The purpose of writing this code is academic.
+
+LVSDE stands for Layered Vertex Splitting Data Embedding.
+For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+)
+
+type ConvergenceCriterion struct {
+	RelativeMeanDisplacementThreshold string `json:"relative_mean_displacement_threshold"`
+	NumberOfConvergedIterations       string `json:"number_of_converged_iterations"`
+}
+
+func resolveConvergenceCriterion(addProblem func(fieldName string, err error, message string), convergenceCriterion *ConvergenceCriterion) *DataEmbedding.ConvergenceCriterion {
+	if convergenceCriterion == nil {
+		return nil
+	}
+
+	resolvedConvergenceCriterion := DataEmbedding.DefaultConvergenceCriterion()
+	if convergenceCriterion.RelativeMeanDisplacementThreshold != "" {
+		resolvedConvergenceCriterion.RelativeMeanDisplacementThreshold = resolveFraction(addProblem, "convergence_criterion.relative_mean_displacement_threshold", convergenceCriterion.RelativeMeanDisplacementThreshold, true, DataEmbedding.DefaultRelativeMeanDisplacementThreshold)
+	}
+	resolvedConvergenceCriterion.NumberOfConvergedIterations = int32(resolveInteger(addProblem, "convergence_criterion.number_of_converged_iterations", convergenceCriterion.NumberOfConvergedIterations, 32, 1, int64(DataEmbedding.DefaultNumberOfConvergedIterations)))
+
+	return &resolvedConvergenceCriterion
+}
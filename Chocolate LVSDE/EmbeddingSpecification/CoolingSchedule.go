@@ -0,0 +1,95 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type CoolingSchedule struct {
+	Type                    string `json:"type"`
+	MinimumTemperatureRatio string `json:"minimum_temperature_ratio"`
+	ShrinkFactor            string `json:"shrink_factor"`
+	Patience                string `json:"patience"`
+}
+
+func resolveCoolingSchedule(addProblem func(fieldName string, err error, message string), coolingSchedule *CoolingSchedule) DataEmbedding.CoolingScheduleConfiguration {
+	coolingScheduleConfiguration := DataEmbedding.DefaultCoolingScheduleConfiguration()
+	if coolingSchedule == nil {
+		return coolingScheduleConfiguration
+	}
+
+	if coolingSchedule.Type != "" {
+		isKnownType := false
+		for _, coolingScheduleType := range DataEmbedding.CoolingScheduleTypes {
+			if coolingSchedule.Type == coolingScheduleType {
+				isKnownType = true
+			}
+		}
+		if isKnownType {
+			coolingScheduleConfiguration.Type = coolingSchedule.Type
+		} else {
+			addProblem("cooling_schedule.type", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not one of %s", coolingSchedule.Type, strings.Join(DataEmbedding.CoolingScheduleTypes, ", ")))
+		}
+	}
+
+	resolveParameter := func(fieldName string, value string, coolingScheduleType string) bool {
+		if value == "" {
+			return false
+		}
+		if coolingSchedule.Type != coolingScheduleType {
+			addProblem("cooling_schedule."+fieldName, ErrorHandling.ErrInconsistentSpecification, "is only used by the "+coolingScheduleType+" cooling schedule")
+			return false
+		}
+		return true
+	}
+
+	if resolveParameter("minimum_temperature_ratio", coolingSchedule.MinimumTemperatureRatio, DataEmbedding.CoolingScheduleTypeExponential) {
+		coolingScheduleConfiguration.MinimumTemperatureRatio = resolveFraction(addProblem, "cooling_schedule.minimum_temperature_ratio", coolingSchedule.MinimumTemperatureRatio, true, DataEmbedding.DefaultMinimumTemperatureRatio)
+	}
+	if resolveParameter("shrink_factor", coolingSchedule.ShrinkFactor, DataEmbedding.CoolingScheduleTypeAdaptive) {
+		coolingScheduleConfiguration.ShrinkFactor = resolveFraction(addProblem, "cooling_schedule.shrink_factor", coolingSchedule.ShrinkFactor, false, DataEmbedding.DefaultShrinkFactor)
+	}
+	if resolveParameter("patience", coolingSchedule.Patience, DataEmbedding.CoolingScheduleTypeAdaptive) {
+		coolingScheduleConfiguration.Patience = int32(resolveInteger(addProblem, "cooling_schedule.patience", coolingSchedule.Patience, 32, 1, int64(DataEmbedding.DefaultPatience)))
+	}
+
+	return coolingScheduleConfiguration
+}
+
+func resolveFraction(addProblem func(fieldName string, err error, message string), fieldName string, value string, isOneAllowed bool, defaultValue float64) float64 {
+	fraction, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(fraction) || math.IsInf(fraction, 0) {
+		addProblem(fieldName, ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not a finite number", value))
+		return defaultValue
+	}
+	if fraction <= 0 || fraction > 1 || (fraction == 1 && !isOneAllowed) {
+		interval := "(0, 1)"
+		if isOneAllowed {
+			interval = "(0, 1]"
+		}
+		addProblem(fieldName, ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("is %g but must be in %s", fraction, interval))
+		return defaultValue
+	}
+	return fraction
+}
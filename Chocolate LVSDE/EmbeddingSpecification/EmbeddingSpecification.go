@@ -18,17 +18,16 @@
 package EmbeddingSpecification
 
 import (
-	"archive/zip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
 	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
 	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/FileReadingOrWriting"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
 	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/PythonInterop"
-	"gopkg.in/mgo.v2/bson"
-	"io"
-	"io/ioutil"
-	"math/rand"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -38,266 +37,297 @@ import (
 )
 
 type EmbeddingSpecification struct {
-	InputFilePath                                   string   `json:"input_file_path"`
-	IsInputFileDistances                            string   `json:"is_input_file_distances"`
-	OutputDirectory                                 string   `json:"output_directory"`
-	ClassLabels                                     []string `json:"class_labels"`
-	ColoursList                                     []string `json:"colours_list"`
-	ImagesFileRedGreenBlueChannels                  string   `json:"images_file_red_green_blue_channels"`
-	ImagesFileGrayscaleSingleChannel                string   `json:"images_file_grayscale_single_channel"`
-	ImagesFileImageWidth                            string   `json:"images_file_image_width"`
-	ImagesFileHasClassLabelNumbers                  string   `json:"images_file_has_class_label_numbers"`
-	RandomSeed                                      string   `json:"random_seed"`
-	RandomState                                     string   `json:"random_state"`
-	PreliminaryToThirtyDimensionsUMAP               string   `json:"preliminary_to_thirty_dimensions_umap"`
-	NumberOfInitialDataAbstractionUnits             string   `json:"number_of_initial_data_abstraction_units"`
-	NumberOfSecondaryDataAbstractionUnits           string   `json:"number_of_secondary_data_abstraction_units"`
-	VisualDensityAdjustmentParameter                string   `json:"visual_density_adjustment_parameter"`
-	NumberOfNeighboursForBuildingNeighbourhoodGraph string   `json:"number_of_neighbours_for_building_neighbourhood_graph"`
-	EvaluationNeighbourhoodSizes                    []string `json:"evaluation_neighbourhood_sizes"`
-	CompareWithOtherMethods                         string   `json:"compare_with_other_methods"`
-	UseCosineDistanceForInputMultiDimensionalData   string   `json:"use_cosine_distance_for_input_multi_dimensional_data"`
+	Name                                            string                `json:"name"`
+	Extends                                         string                `json:"extends"`
+	InputFilePath                                   string                `json:"input_file_path"`
+	IsInputFileDistances                            string                `json:"is_input_file_distances"`
+	OutputDirectory                                 string                `json:"output_directory"`
+	ClassLabels                                     []string              `json:"class_labels"`
+	ColoursList                                     []string              `json:"colours_list"`
+	ImagesFileRedGreenBlueChannels                  string                `json:"images_file_red_green_blue_channels"`
+	ImagesFileGrayscaleSingleChannel                string                `json:"images_file_grayscale_single_channel"`
+	ImagesFileImageWidth                            string                `json:"images_file_image_width"`
+	ImagesFileHasClassLabelNumbers                  string                `json:"images_file_has_class_label_numbers"`
+	RandomSeed                                      string                `json:"random_seed"`
+	RandomState                                     string                `json:"random_state"`
+	MasterSeed                                      string                `json:"master_seed"`
+	PreliminaryToThirtyDimensionsUMAP               string                `json:"preliminary_to_thirty_dimensions_umap"`
+	NumberOfInitialDataAbstractionUnits             string                `json:"number_of_initial_data_abstraction_units"`
+	NumberOfSecondaryDataAbstractionUnits           string                `json:"number_of_secondary_data_abstraction_units"`
+	VisualDensityAdjustmentParameter                string                `json:"visual_density_adjustment_parameter"`
+	NumberOfNeighboursForBuildingNeighbourhoodGraph string                `json:"number_of_neighbours_for_building_neighbourhood_graph"`
+	EvaluationNeighbourhoodSizes                    []string              `json:"evaluation_neighbourhood_sizes"`
+	CompareWithOtherMethods                         string                `json:"compare_with_other_methods"`
+	UseCosineDistanceForInputMultiDimensionalData   string                `json:"use_cosine_distance_for_input_multi_dimensional_data"`
+	OutputDirectoryPolicy                           string                `json:"output_directory_policy"`
+	PhaseSchedule                                   *PhaseSchedule        `json:"phase_schedule"`
+	CoolingSchedule                                 *CoolingSchedule      `json:"cooling_schedule"`
+	ConvergenceCriterion                            *ConvergenceCriterion `json:"convergence_criterion"`
+	RepulsiveForces                                 *RepulsiveForces      `json:"repulsive_forces"`
+	Multilevel                                      *Multilevel           `json:"multilevel"`
+	NearestNeighbours                               *NearestNeighbours    `json:"nearest_neighbours"`
+	DistanceStorage                                 string                `json:"distance_storage"`
+	ParameterSweep                                  *ParameterSweep       `json:"parameter_sweep"`
+	StabilityAnalysis                               *StabilityAnalysis    `json:"stability_analysis"`
+	FieldOverrides                                  []FieldOverride       `json:"-"`
+	SpecificationFilePath                           string                `json:"-"`
 }
 
+var DefaultColoursList = []string{"#8AB9F1", "#6F4E37", "#00FF00", "#8B008B", "#00356B", "#c24100", "#4F7942", "#FF66CC", "#F4C430", "#8806CE"}
+
+var DefaultClassLabels = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+
 type EmbeddingSpecifications struct {
+	SpecVersion             int                      `json:"spec_version"`
+	Defaults                *EmbeddingSpecification  `json:"defaults"`
 	EmbeddingSpecifications []EmbeddingSpecification `json:"embedding_specifications"`
 }
 
-func ReadEmbeddingSpecification(embeddingSpecificationFilePath string) EmbeddingSpecifications {
-	_, err := os.Stat(embeddingSpecificationFilePath)
-	if os.IsNotExist(err) {
-		panic("Not finished successfully. Embedding specifications file does not exist.")
+func ReadEmbeddingSpecification(embeddingSpecificationFilePath string) (EmbeddingSpecifications, error) {
+	embeddingSpecifications, problems := readEmbeddingSpecificationsFile(embeddingSpecificationFilePath)
+	if len(problems) > 0 {
+		return embeddingSpecifications, problems[0]
 	}
 
-	var embeddingSpecifications EmbeddingSpecifications
-
-	var embeddingSpecificationFile *os.File
-
-	embeddingSpecificationFile, err = os.Open(embeddingSpecificationFilePath)
-
-	if err != nil {
-		panic("Not finished successfully. Could not read the embedding specifications file")
-	}
+	return embeddingSpecifications, nil
+}
 
-	defer embeddingSpecificationFile.Close()
+type EmbeddingSpecificationRunSummary struct {
+	SpecificationIndex int
+	OutputDirectory    string
+	Status             string
+	WallTime           time.Duration
+	KNNAccuracies      []string
+	Err                error
+}
 
-	embeddingSpecificationFileBytes, _ := ioutil.ReadAll(embeddingSpecificationFile)
+func RunEmbeddingSpecifications(ctx context.Context, embeddingSpecifications []EmbeddingSpecification) ([]EmbeddingSpecificationRunSummary, error) {
+	runSummaries := make([]EmbeddingSpecificationRunSummary, len(embeddingSpecifications))
+	var firstErr error
+	numberOfFailures := 0
 
-	err = json.Unmarshal(embeddingSpecificationFileBytes, &embeddingSpecifications)
+	for i := 0; i < len(embeddingSpecifications); i++ {
+		if ctx.Err() != nil {
+			runSummaries[i].SpecificationIndex = i
+			runSummaries[i].OutputDirectory = embeddingSpecifications[i].OutputDirectory
+			runSummaries[i].Err = ErrorHandling.NewCancellationError("waiting to start", 0, ctx.Err())
+			runSummaries[i].Status = "skipped: " + runSummaries[i].Err.Error()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("embedding specification %d: %w", i, runSummaries[i].Err)
+			}
+			numberOfFailures++
+			continue
+		}
 
-	if err != nil {
-		panic("Not finished successfully. Could not parse the embedding specifications file.")
+		Logging.SetSpecificationIndex(i)
+		Logging.Info("Running embedding specification", Logging.Fields{"number_of_embedding_specifications": len(embeddingSpecifications), "output_directory": embeddingSpecifications[i].OutputDirectory})
+		runSummaries[i] = runEmbeddingSpecificationIsolated(ctx, i, embeddingSpecifications[i])
+		if runSummaries[i].Err != nil {
+			Logging.Error("Embedding specification not finished", Logging.Fields{"status": runSummaries[i].Status})
+			if firstErr == nil {
+				firstErr = fmt.Errorf("embedding specification %d: %w", i, runSummaries[i].Err)
+			}
+			numberOfFailures++
+		}
 	}
 
-	for i := 0; i < len(embeddingSpecifications.EmbeddingSpecifications); i++ {
-		embeddingSpecification := &embeddingSpecifications.EmbeddingSpecifications[i]
+	Logging.SetSpecificationIndex(-1)
 
-		embeddingSpecification.InputFilePath = filepath.Join(filepath.Dir(embeddingSpecificationFilePath), embeddingSpecification.InputFilePath)
-		embeddingSpecification.OutputDirectory = filepath.Join(filepath.Dir(embeddingSpecificationFilePath), embeddingSpecification.OutputDirectory)
-
-		if embeddingSpecification.ImagesFileGrayscaleSingleChannel != "" {
-			embeddingSpecification.ImagesFileGrayscaleSingleChannel = filepath.Join(filepath.Dir(embeddingSpecificationFilePath), embeddingSpecification.ImagesFileGrayscaleSingleChannel)
-		}
+	if numberOfFailures > 1 {
+		return runSummaries, fmt.Errorf("%d of %d embedding specifications failed, first failure: %w", numberOfFailures, len(embeddingSpecifications), firstErr)
+	}
+	return runSummaries, firstErr
+}
 
-		if embeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
-			embeddingSpecification.ImagesFileRedGreenBlueChannels = filepath.Join(filepath.Dir(embeddingSpecificationFilePath), embeddingSpecification.ImagesFileRedGreenBlueChannels)
+func runEmbeddingSpecificationIsolated(ctx context.Context, specificationIndex int, embeddingSpecification EmbeddingSpecification) (runSummary EmbeddingSpecificationRunSummary) {
+	runSummary.SpecificationIndex = specificationIndex
+	runSummary.OutputDirectory = embeddingSpecification.OutputDirectory
+	startTime := time.Now()
+
+	defer func() {
+		runSummary.WallTime = time.Since(startTime)
+		if recovered := recover(); recovered != nil {
+			runSummary.Err = fmt.Errorf("%v", recovered)
+		}
+		if errors.Is(runSummary.Err, ErrorHandling.ErrCancelled) {
+			runSummary.Status = runSummary.Err.Error()
+		} else if runSummary.Err != nil {
+			runSummary.Status = "failed: " + runSummary.Err.Error()
+		}
+	}()
+
+	runResult, err := RunEmbeddingSpecification(ctx, embeddingSpecification)
+	runSummary.OutputDirectory = runResult.OutputDirectory
+	runSummary.KNNAccuracies = runResult.KNNAccuracies
+	runSummary.Err = err
+	if runSummary.Err == nil {
+		runSummary.Status = "finished"
+		if runResult.IsSkipped {
+			runSummary.Status = "skipped: already complete"
+		} else if len(runResult.ParameterSweepRunResults) > 0 {
+			runSummary.Status = fmt.Sprintf("finished: %d runs", len(runResult.ParameterSweepRunResults))
 		}
 	}
+	return runSummary
+}
 
-	return embeddingSpecifications
+type EmbeddingSpecificationRunResult struct {
+	OutputDirectory          string
+	KNNAccuracies            []string
+	IsSkipped                bool
+	ParameterSweepRunResults []ParameterSweepRunResult
 }
 
-func RunEmbeddingSpecifications(embeddingSpecifications []EmbeddingSpecification) {
-	for i := 0; i < len(embeddingSpecifications); i++ {
-		runtime.GC()
-		embeddingSpecification := embeddingSpecifications[i]
-		var dataAbstractionSet DataAbstraction.DataAbstractionSet
+func RunEmbeddingSpecification(ctx context.Context, embeddingSpecification EmbeddingSpecification) (EmbeddingSpecificationRunResult, error) {
+	var runResult EmbeddingSpecificationRunResult
+	runResult.OutputDirectory = embeddingSpecification.OutputDirectory
 
-		_, err := os.Stat(embeddingSpecification.OutputDirectory)
-		if os.IsNotExist(err) {
-			os.MkdirAll(embeddingSpecification.OutputDirectory, FileReadingOrWriting.Chmod)
-		} else {
-			panic("Not finished successfully. Output directory cannot be created because it exists.")
-		}
+	resolvedEmbeddingSpecification, problems := ResolveEmbeddingSpecification(embeddingSpecification)
+	if len(problems) > 0 {
+		return runResult, problems[0]
+	}
 
-		coloursList := []string{"#8AB9F1", "#6F4E37", "#00FF00", "#8B008B", "#00356B", "#c24100", "#4F7942", "#FF66CC", "#F4C430", "#8806CE"}
-		if embeddingSpecification.ColoursList != nil {
-			coloursList = embeddingSpecification.ColoursList
-		}
+	if embeddingSpecification.StabilityAnalysis != nil {
+		return runStabilityAnalysis(ctx, embeddingSpecification, resolvedEmbeddingSpecification)
+	}
 
-		classLabels := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
-		if embeddingSpecification.ClassLabels != nil {
-			classLabels = embeddingSpecification.ClassLabels
-		}
+	if embeddingSpecification.ParameterSweep != nil {
+		return runParameterSweep(ctx, embeddingSpecification, resolvedEmbeddingSpecification)
+	}
 
-		if embeddingSpecification.ColoursList == nil && embeddingSpecification.ClassLabels != nil {
-			if len(classLabels) > len(coloursList) {
-				panic("Not finished successfully. Inconsistent embedding specifications file.")
-			}
-			coloursList = coloursList[:len(classLabels)]
-		}
+	outputDirectory, completionMarker, err := prepareOutputDirectory(resolvedEmbeddingSpecification.OutputDirectory, resolvedEmbeddingSpecification.OutputDirectoryPolicy, resolvedEmbeddingSpecification.ProtectedFilePaths())
+	if err != nil {
+		return runResult, err
+	}
+	runResult.OutputDirectory = outputDirectory
 
-		if len(coloursList) != len(classLabels) {
-			panic("Not finished successfully. Inconsistent embedding specifications file.")
-		}
+	if completionMarker != nil {
+		Logging.Info("Skipping the embedding specification because its output directory has a completion marker.", Logging.Fields{"output_directory": outputDirectory, "finished_at": completionMarker.FinishedAt})
+		runResult.KNNAccuracies = completionMarker.KNNAccuracies
+		runResult.IsSkipped = true
+		return runResult, nil
+	}
 
-		var isInputFileDistances bool = false
-		if embeddingSpecification.IsInputFileDistances == "true" {
-			isInputFileDistances = true
-			numberOfInitialDataAbstractionUnits, err := strconv.ParseInt(embeddingSpecification.NumberOfInitialDataAbstractionUnits, 10, 32)
-			if err != nil {
-				panic("Not finished successfully. Could not parse the embedding specifications file.")
-			}
-			fmt.Println("Reading input file...")
-			dataAbstractionSet = FileReadingOrWriting.ReadDataAbstractionSetFromDistancesFile(embeddingSpecification.InputFilePath, int32(numberOfInitialDataAbstractionUnits), int32(len(coloursList)-1))
-			fmt.Println("Reading input file finished.")
-		} else if embeddingSpecification.IsInputFileDistances == "false" {
-			isInputFileDistances = false
-			numberOfInitialDataAbstractionUnits, err := strconv.ParseInt(embeddingSpecification.NumberOfInitialDataAbstractionUnits, 10, 32)
-			if err != nil {
-				panic("Not finished successfully. Could not parse the embedding specifications file.")
-			}
-			fmt.Println("Reading input file...")
-			dataAbstractionSet = FileReadingOrWriting.ReadDataAbstractionSetFromMultiDimensionalDataFile(embeddingSpecification.InputFilePath, int32(numberOfInitialDataAbstractionUnits), int32(len(coloursList)-1))
-			fmt.Println("Reading input file finished.")
-		} else {
-			panic("Not finished successfully. Could not parse the embedding specifications file.")
-		}
+	embeddingSpecification.OutputDirectory = outputDirectory
+	resolvedEmbeddingSpecification.OutputDirectory = outputDirectory
 
-		var dataEmbeddingTechniqueLVSDE DataEmbedding.DataEmbeddingTechniqueLVSDE
-		if embeddingSpecification.VisualDensityAdjustmentParameter == "" {
-			dataEmbeddingTechniqueLVSDE.VisualDensityAdjustmentParameter = 0.9
-		} else {
-			var err error
-			dataEmbeddingTechniqueLVSDE.VisualDensityAdjustmentParameter, err = strconv.ParseFloat(embeddingSpecification.VisualDensityAdjustmentParameter, 64)
-			if err != nil {
-				panic("Not finished successfully. Could not parse the embedding specifications file.")
-			}
-		}
+	runResult.KNNAccuracies, err = runResolvedEmbeddingSpecification(ctx, embeddingSpecification, resolvedEmbeddingSpecification)
+	if err != nil {
+		return runResult, err
+	}
 
-		if embeddingSpecification.NumberOfNeighboursForBuildingNeighbourhoodGraph == "" {
-			dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph = int32(len(dataAbstractionSet.DataAbstractionUnits) / 3)
-		} else {
-			numberOfNeighboursForBuildingNeighbourhoodGraph, err := strconv.ParseInt(embeddingSpecification.NumberOfNeighboursForBuildingNeighbourhoodGraph, 10, 32)
-			if err != nil {
-				panic("Not finished successfully. Could not parse the embedding specifications file.")
-			}
-			dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph = int32(numberOfNeighboursForBuildingNeighbourhoodGraph)
-		}
+	err = writeCompletionMarker(outputDirectory, runResult.KNNAccuracies)
+	return runResult, err
+}
 
-		var imageWidth int64 = -1
-		if embeddingSpecification.ImagesFileImageWidth != "" {
-			var err error
-			imageWidth, err = strconv.ParseInt(embeddingSpecification.ImagesFileImageWidth, 10, 32)
-			if err != nil {
-				panic("Not finished successfully. Could not parse the embedding specifications file.")
-			}
-		}
+func runResolvedEmbeddingSpecification(ctx context.Context, embeddingSpecification EmbeddingSpecification, resolvedEmbeddingSpecification ResolvedEmbeddingSpecification) ([]string, error) {
+	runtime.GC()
 
-		if embeddingSpecification.ImagesFileGrayscaleSingleChannel != "" && embeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
-			panic("Not finished successfully. Inconsistent embedding specifications file.")
-		}
+	dataAbstractionSet, preparation, err := prepareDataAbstractionSet(ctx, resolvedEmbeddingSpecification)
+	if err != nil {
+		return nil, err
+	}
 
-		if embeddingSpecification.ImagesFileGrayscaleSingleChannel != "" {
-			if embeddingSpecification.ImagesFileHasClassLabelNumbers == "true" {
-				FileReadingOrWriting.ReadImagesFileGrayscaleSingleChannel(embeddingSpecification.ImagesFileGrayscaleSingleChannel, &dataAbstractionSet, int32(imageWidth), true)
-			} else if embeddingSpecification.ImagesFileHasClassLabelNumbers == "false" || embeddingSpecification.ImagesFileHasClassLabelNumbers == "" {
-				FileReadingOrWriting.ReadImagesFileGrayscaleSingleChannel(embeddingSpecification.ImagesFileGrayscaleSingleChannel, &dataAbstractionSet, int32(imageWidth), false)
-			} else {
-				panic("Not finished successfully.")
-			}
-		}
+	return embedPreparedDataAbstractionSet(ctx, embeddingSpecification, resolvedEmbeddingSpecification, dataAbstractionSet, preparation, false)
+}
 
-		if embeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
-			if embeddingSpecification.ImagesFileHasClassLabelNumbers == "true" {
-				FileReadingOrWriting.ReadImagesFileRedGreenBlueChannels(embeddingSpecification.ImagesFileRedGreenBlueChannels, &dataAbstractionSet, int32(imageWidth), true)
-			} else if embeddingSpecification.ImagesFileHasClassLabelNumbers == "false" || embeddingSpecification.ImagesFileHasClassLabelNumbers == "" {
-				FileReadingOrWriting.ReadImagesFileRedGreenBlueChannels(embeddingSpecification.ImagesFileRedGreenBlueChannels, &dataAbstractionSet, int32(imageWidth), false)
-			} else {
-				panic("Not finished successfully.")
-			}
-		}
+func prepareDataAbstractionSet(ctx context.Context, resolvedEmbeddingSpecification ResolvedEmbeddingSpecification) (dataAbstractionSet DataAbstraction.DataAbstractionSet, preparation dataAbstractionSetPreparation, err error) {
+	var stageTimer stageTimer
+	defer func() {
+		stageTimer.finishStage()
+		preparation.StageTimings = stageTimer.stageTimings
+	}()
 
-		var useCosineDistance bool = false
-		if embeddingSpecification.UseCosineDistanceForInputMultiDimensionalData == "" || embeddingSpecification.UseCosineDistanceForInputMultiDimensionalData == "false" {
-			useCosineDistance = false
-		} else if embeddingSpecification.UseCosineDistanceForInputMultiDimensionalData == "true" {
-			useCosineDistance = true
-		} else {
-			panic("Not finished successfully. Could not parse the embedding specifications file.")
-		}
+	preparation.PythonVersion = PythonVersionNotUsed
+	preparation.UMAPLearnVersion = PythonVersionNotUsed
+	preparation.ScikitLearnVersion = PythonVersionNotUsed
 
-		if embeddingSpecification.RandomState != "" && embeddingSpecification.RandomSeed != "" {
-			panic("Not finished successfully. Inconsistent embedding specifications file.")
-		}
+	coloursList := resolvedEmbeddingSpecification.ColoursList
+	isInputFileDistances := resolvedEmbeddingSpecification.IsInputFileDistances
 
-		var randomState int64 = 5
-		if embeddingSpecification.RandomState != "" {
-			var err error
-			randomState, err = strconv.ParseInt(embeddingSpecification.RandomState, 10, 32)
+	stageTimer.startStage("reading_input")
+	preparation.InputFiles, err = hashInputFiles(resolvedEmbeddingSpecification)
+	if err != nil {
+		return dataAbstractionSet, preparation, err
+	}
 
-			if err != nil || randomState < 0 {
-				panic("Not finished successfully. Could not parse the embedding specifications file.")
-			}
-		}
+	Logging.Info("Reading input file...", Logging.Fields{"input_file_path": resolvedEmbeddingSpecification.InputFilePath})
+	if isInputFileDistances {
+		dataAbstractionSet, err = FileReadingOrWriting.ReadDataAbstractionSetFromDistancesFile(resolvedEmbeddingSpecification.InputFilePath, resolvedEmbeddingSpecification.NumberOfInitialDataAbstractionUnits, int32(len(coloursList)-1))
+	} else {
+		dataAbstractionSet, err = FileReadingOrWriting.ReadDataAbstractionSetFromMultiDimensionalDataFile(resolvedEmbeddingSpecification.InputFilePath, resolvedEmbeddingSpecification.NumberOfInitialDataAbstractionUnits, int32(len(coloursList)-1))
+	}
+	if err != nil {
+		return dataAbstractionSet, preparation, err
+	}
+	Logging.Info("Reading input file finished.", Logging.Fields{"number_of_data_abstraction_units": len(dataAbstractionSet.DataAbstractionUnits)})
 
-		compareWithOtherMethods := false
-		if embeddingSpecification.CompareWithOtherMethods == "true" {
-			compareWithOtherMethods = true
-		} else if embeddingSpecification.CompareWithOtherMethods != "" && embeddingSpecification.CompareWithOtherMethods != "false" {
-			panic("Not finished successfully. Could not parse the embedding specifications file.")
+	if resolvedEmbeddingSpecification.ImagesFileGrayscaleSingleChannel != "" {
+		err = FileReadingOrWriting.ReadImagesFileGrayscaleSingleChannel(resolvedEmbeddingSpecification.ImagesFileGrayscaleSingleChannel, &dataAbstractionSet, resolvedEmbeddingSpecification.ImagesFileImageWidth, resolvedEmbeddingSpecification.ImagesFileHasClassLabelNumbers)
+		if err != nil {
+			return dataAbstractionSet, preparation, err
 		}
+	}
 
-		preliminaryToThirtyDimensionsUMAP := false
-		if embeddingSpecification.PreliminaryToThirtyDimensionsUMAP == "true" || embeddingSpecification.PreliminaryToThirtyDimensionsUMAP == "" {
-			preliminaryToThirtyDimensionsUMAP = true
-		} else if embeddingSpecification.PreliminaryToThirtyDimensionsUMAP != "false" {
-			panic("Not finished successfully. Could not parse the embedding specifications file.")
+	if resolvedEmbeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
+		err = FileReadingOrWriting.ReadImagesFileRedGreenBlueChannels(resolvedEmbeddingSpecification.ImagesFileRedGreenBlueChannels, &dataAbstractionSet, resolvedEmbeddingSpecification.ImagesFileImageWidth, resolvedEmbeddingSpecification.ImagesFileHasClassLabelNumbers)
+		if err != nil {
+			return dataAbstractionSet, preparation, err
 		}
+	}
+
+	useCosineDistance := resolvedEmbeddingSpecification.UseCosineDistanceForInputMultiDimensionalData
+	seeds := resolvedEmbeddingSpecification.Seeds
+	compareWithOtherMethods := resolvedEmbeddingSpecification.CompareWithOtherMethods
+	preliminaryToThirtyDimensionsUMAP := resolvedEmbeddingSpecification.PreliminaryToThirtyDimensionsUMAP
 
-		comparisonPythonCode := `
-		print('Performing UMAP to 2 dimensions for comparison..., timestamp (Unix nanoseconds): '+str(time.time_ns()))
+	comparisonPythonCode := `
+		log('info', 'umap_comparison', 'Performing UMAP to 2 dimensions for comparison...')
 		twoDimUMAP=umap.UMAP(n_components=2, random_state=%d%s).fit_transform(input)
 		for i in range(0,numberOfDataAbstractionUnitsOutput):
 			for j in range(0,2):
 				output.append(float(twoDimUMAP[i,j]))
-		print('Two dimensional UMAP for comparison finished, timestamp (Unix nanoseconds): '+str(time.time_ns()))
-		print('Performing t-SNE to 2 dimensions for comparison..., timestamp (Unix nanoseconds): '+str(time.time_ns()))
+		log('info', 'umap_comparison', 'Two dimensional UMAP for comparison finished.')
+		log('info', 'tsne_comparison', 'Performing t-SNE to 2 dimensions for comparison...')
 		twoDimTSNE=TSNE(n_components=2, init='random', learning_rate='auto', method='barnes_hut', random_state=%d%s).fit_transform(input)
 		for i in range(0,numberOfDataAbstractionUnitsOutput):
 			for j in range(0,2):
 				output.append(float(twoDimTSNE[i,j]))
-		print('Two dimensional t-SNE for comparison finished., timestamp (Unix nanoseconds): '+str(time.time_ns()))`
-
-		if isInputFileDistances {
-			comparisonPythonCode = fmt.Sprintf(comparisonPythonCode, randomState, ", metric='precomputed'", randomState, ", metric='precomputed'")
-		} else if useCosineDistance {
-			comparisonPythonCode = fmt.Sprintf(comparisonPythonCode, randomState, ", metric='cosine'", randomState, ", metric='cosine'")
-		} else {
-			comparisonPythonCode = fmt.Sprintf(comparisonPythonCode, randomState, "", randomState, "")
-		}
+		log('info', 'tsne_comparison', 'Two dimensional t-SNE for comparison finished.')`
+
+	if isInputFileDistances {
+		comparisonPythonCode = fmt.Sprintf(comparisonPythonCode, seeds.UMAP, ", metric='precomputed'", seeds.TSNE, ", metric='precomputed'")
+	} else if useCosineDistance {
+		comparisonPythonCode = fmt.Sprintf(comparisonPythonCode, seeds.UMAP, ", metric='cosine'", seeds.TSNE, ", metric='cosine'")
+	} else {
+		comparisonPythonCode = fmt.Sprintf(comparisonPythonCode, seeds.UMAP, "", seeds.TSNE, "")
+	}
 
-		thirtyDimensionalUmapPythonCode := `
-		print('Performing UMAP to 30 dimensions as a preliminary step...')
+	thirtyDimensionalUmapPythonCode := `
+		log('info', 'preliminary_umap', 'Performing UMAP to 30 dimensions as a preliminary step...')
 		thirtyDim=umap.UMAP(n_components=30, random_state=%d%s).fit_transform(input)
-		print('Thirty dimensional UMAP as a preliminary step finished, timestamp (Unix nanoseconds): '+str(time.time_ns()))
+		log('info', 'preliminary_umap', 'Thirty dimensional UMAP as a preliminary step finished.')
 		for i in range(0,numberOfDataAbstractionUnitsOutput):
 			for j in range(0,30):
 				output.append(float(thirtyDim[i,j]))`
 
-		if isInputFileDistances {
-			thirtyDimensionalUmapPythonCode = fmt.Sprintf(thirtyDimensionalUmapPythonCode, randomState, ", metric='precomputed'")
-		} else if useCosineDistance {
-			thirtyDimensionalUmapPythonCode = fmt.Sprintf(thirtyDimensionalUmapPythonCode, randomState, ", metric='cosine'")
-		} else {
-			thirtyDimensionalUmapPythonCode = fmt.Sprintf(thirtyDimensionalUmapPythonCode, randomState, "")
-		}
+	if isInputFileDistances {
+		thirtyDimensionalUmapPythonCode = fmt.Sprintf(thirtyDimensionalUmapPythonCode, seeds.UMAP, ", metric='precomputed'")
+	} else if useCosineDistance {
+		thirtyDimensionalUmapPythonCode = fmt.Sprintf(thirtyDimensionalUmapPythonCode, seeds.UMAP, ", metric='cosine'")
+	} else {
+		thirtyDimensionalUmapPythonCode = fmt.Sprintf(thirtyDimensionalUmapPythonCode, seeds.UMAP, "")
+	}
 
-		if preliminaryToThirtyDimensionsUMAP || compareWithOtherMethods {
+	if preliminaryToThirtyDimensionsUMAP || compareWithOtherMethods {
 
-			functionCode := `
-def SomeDimensionalityReductions(*x):
+		functionCode := `
+logRecords=[]
+def SomeDimensionalityReductions(*x, logRecords=logRecords):
 	import sys
+	import time
+	def log(level, stage, message):
+		logRecords.append(level+'\t'+stage+'\t'+str(time.time_ns())+'\t'+message)
 	output=[]
 	try:
 		numberOfDataAbstractionUnits=int(x[0])
@@ -311,425 +341,410 @@ def SomeDimensionalityReductions(*x):
 		import numpy as np
 		import umap
 		from sklearn.manifold import TSNE
-		import time
 		input=np.array(input)`
 
-			if compareWithOtherMethods {
-				functionCode += comparisonPythonCode
-			}
-			if preliminaryToThirtyDimensionsUMAP {
-				functionCode += `
-		print('LVSDE embedding started at '+time.strftime('%a %b %d %H:%M:%S %Z %Y',time.localtime())+', timestamp (Unix nanoseconds): '+str(time.time_ns()))`
-				functionCode += thirtyDimensionalUmapPythonCode
-			}
+		if compareWithOtherMethods {
+			functionCode += comparisonPythonCode
+		}
+		if preliminaryToThirtyDimensionsUMAP {
 			functionCode += `
+		log('info', 'preliminary_umap', 'LVSDE embedding started.')`
+			functionCode += thirtyDimensionalUmapPythonCode
+		}
+		functionCode += `
 	except:
-		print(str(sys.exc_info()))
+		log('error', 'python', str(sys.exc_info()))
+		raise
 	return tuple(output)
 `
-			var functionParameters []float64
-			if isInputFileDistances {
-				functionParameters = make([]float64, len(dataAbstractionSet.DataAbstractionUnits)*len(dataAbstractionSet.DataAbstractionUnits)+2)
-			} else {
-				functionParameters = make([]float64, len(dataAbstractionSet.DataAbstractionUnits)*len(dataAbstractionSet.DataAbstractionUnits[0].OriginalSpaceCoordinates)+2)
-			}
+		numberOfSecondaryDataAbstractionUnits := int64(len(dataAbstractionSet.DataAbstractionUnits))
+		if resolvedEmbeddingSpecification.NumberOfSecondaryDataAbstractionUnits != -1 {
+			numberOfSecondaryDataAbstractionUnits = int64(resolvedEmbeddingSpecification.NumberOfSecondaryDataAbstractionUnits)
+		}
 
-			functionParameters[0] = float64(len(dataAbstractionSet.DataAbstractionUnits))
-			numberOfSecondaryDataAbstractionUnits := int64(len(dataAbstractionSet.DataAbstractionUnits))
-			if embeddingSpecification.NumberOfSecondaryDataAbstractionUnits != "" {
-				var err error
-				numberOfSecondaryDataAbstractionUnits, err = strconv.ParseInt(embeddingSpecification.NumberOfSecondaryDataAbstractionUnits, 10, 32)
-				if err != nil {
-					panic("Not finished successfully. Could not parse the embedding specifications file.")
-				}
-			}
-			functionParameters[1] = float64(numberOfSecondaryDataAbstractionUnits)
+		if seeds.Subsampling != nil && numberOfSecondaryDataAbstractionUnits < int64(len(dataAbstractionSet.DataAbstractionUnits)) {
+			preparation.OriginalDataAbstractionUnitNumbers = dataAbstractionSet.Subsample(int(numberOfSecondaryDataAbstractionUnits), *seeds.Subsampling)
+			Logging.Info("Secondary data abstraction units subsampled.", Logging.Fields{"number_of_secondary_data_abstraction_units": numberOfSecondaryDataAbstractionUnits, "subsampling_seed": *seeds.Subsampling})
+		}
 
-			if isInputFileDistances {
-				for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
-					for k := 0; k < len(dataAbstractionSet.DataAbstractionUnits); k++ {
-						functionParameters[j*len(dataAbstractionSet.DataAbstractionUnits)+k+2] = dataAbstractionSet.DistancesBeforeTransformation[j][k]
-					}
-				}
-			} else {
-				for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
-					dataAbstractionUnit := dataAbstractionSet.DataAbstractionUnits[j]
-					for k := 0; k < len(dataAbstractionUnit.OriginalSpaceCoordinates); k++ {
-						functionParameters[j*len(dataAbstractionUnit.OriginalSpaceCoordinates)+k+2] = dataAbstractionUnit.OriginalSpaceCoordinates[k]
-					}
+		var functionParameters []float64
+		if isInputFileDistances {
+			functionParameters = make([]float64, len(dataAbstractionSet.DataAbstractionUnits)*len(dataAbstractionSet.DataAbstractionUnits)+2)
+		} else {
+			functionParameters = make([]float64, len(dataAbstractionSet.DataAbstractionUnits)*len(dataAbstractionSet.DataAbstractionUnits[0].OriginalSpaceCoordinates)+2)
+		}
+
+		functionParameters[0] = float64(len(dataAbstractionSet.DataAbstractionUnits))
+		functionParameters[1] = float64(numberOfSecondaryDataAbstractionUnits)
+
+		if isInputFileDistances {
+			for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
+				for k := 0; k < len(dataAbstractionSet.DataAbstractionUnits); k++ {
+					functionParameters[j*len(dataAbstractionSet.DataAbstractionUnits)+k+2] = dataAbstractionSet.DistancesBeforeTransformation[j][k]
 				}
 			}
-
-			functionOutputSize := 30 * int(numberOfSecondaryDataAbstractionUnits)
-			if compareWithOtherMethods && preliminaryToThirtyDimensionsUMAP {
-				functionOutputSize = 34 * int(numberOfSecondaryDataAbstractionUnits)
-			} else if compareWithOtherMethods && !preliminaryToThirtyDimensionsUMAP {
-				functionOutputSize = 4 * int(numberOfSecondaryDataAbstractionUnits)
+		} else {
+			for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
+				dataAbstractionUnit := dataAbstractionSet.DataAbstractionUnits[j]
+				for k := 0; k < len(dataAbstractionUnit.OriginalSpaceCoordinates); k++ {
+					functionParameters[j*len(dataAbstractionUnit.OriginalSpaceCoordinates)+k+2] = dataAbstractionUnit.OriginalSpaceCoordinates[k]
+				}
 			}
+		}
 
-			output := PythonInterop.RunPythonFunction(functionCode, "SomeDimensionalityReductions", functionParameters, functionOutputSize)
+		functionOutputSize := 30 * int(numberOfSecondaryDataAbstractionUnits)
+		if compareWithOtherMethods && preliminaryToThirtyDimensionsUMAP {
+			functionOutputSize = 34 * int(numberOfSecondaryDataAbstractionUnits)
+		} else if compareWithOtherMethods && !preliminaryToThirtyDimensionsUMAP {
+			functionOutputSize = 4 * int(numberOfSecondaryDataAbstractionUnits)
+		}
 
-			dataAbstractionSet.DataAbstractionUnits = dataAbstractionSet.DataAbstractionUnits[:numberOfSecondaryDataAbstractionUnits]
+		stageTimer.startStage("python")
+		preparation.PythonVersion, preparation.UMAPLearnVersion, preparation.ScikitLearnVersion = readPythonVersions()
+		output, err := PythonInterop.RunPythonFunction(functionCode, "SomeDimensionalityReductions", functionParameters, functionOutputSize)
+		if err != nil {
+			return dataAbstractionSet, preparation, err
+		}
 
-			var outputsUsed int = 0
+		dataAbstractionSet.DataAbstractionUnits = dataAbstractionSet.DataAbstractionUnits[:numberOfSecondaryDataAbstractionUnits]
 
-			if compareWithOtherMethods {
-				for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
-					dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
-					dataAbstractionUnit.ComparisonVisualSpaceCoordinates = make([][2]float64, 2)
-					for k := 0; k < 2; k++ {
-						dataAbstractionUnit.ComparisonVisualSpaceCoordinates[0][k] = output[j*2+k+outputsUsed]
-					}
-				}
-				outputsUsed += 2 * int(numberOfSecondaryDataAbstractionUnits)
+		var outputsUsed int = 0
 
-				for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
-					dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
-					for k := 0; k < 2; k++ {
-						dataAbstractionUnit.ComparisonVisualSpaceCoordinates[1][k] = output[j*2+k+outputsUsed]
-					}
+		if compareWithOtherMethods {
+			for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
+				dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
+				dataAbstractionUnit.ComparisonVisualSpaceCoordinates = make([][2]float64, 2)
+				for k := 0; k < 2; k++ {
+					dataAbstractionUnit.ComparisonVisualSpaceCoordinates[0][k] = output[j*2+k+outputsUsed]
 				}
-				outputsUsed += 2 * int(numberOfSecondaryDataAbstractionUnits)
 			}
+			outputsUsed += 2 * int(numberOfSecondaryDataAbstractionUnits)
 
-			if preliminaryToThirtyDimensionsUMAP {
-				for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
-					dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
-					dataAbstractionUnit.ThirtyDimensionalSpaceCoordinates = make([]float64, 30)
-					for k := 0; k < 30; k++ {
-						dataAbstractionUnit.ThirtyDimensionalSpaceCoordinates[k] = output[j*30+k+outputsUsed]
-					}
+			for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
+				dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
+				for k := 0; k < 2; k++ {
+					dataAbstractionUnit.ComparisonVisualSpaceCoordinates[1][k] = output[j*2+k+outputsUsed]
 				}
-				outputsUsed += 30 * int(numberOfSecondaryDataAbstractionUnits)
-			} else {
-				fmt.Println("LVSDE embedding started at", time.Now().Format(time.UnixDate), ", timestamp (Unix nanoseconds):", time.Now().UnixMicro())
 			}
+			outputsUsed += 2 * int(numberOfSecondaryDataAbstractionUnits)
+		}
 
-			if dataAbstractionSet.DistancesBeforeTransformation != nil {
-				dataAbstractionSet.DistancesBeforeThirtyDimensionalUMAP = dataAbstractionSet.DistancesBeforeThirtyDimensionalUMAP
+		if preliminaryToThirtyDimensionsUMAP {
+			for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
+				dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
+				dataAbstractionUnit.ThirtyDimensionalSpaceCoordinates = make([]float64, 30)
+				for k := 0; k < 30; k++ {
+					dataAbstractionUnit.ThirtyDimensionalSpaceCoordinates[k] = output[j*30+k+outputsUsed]
+				}
 			}
+			outputsUsed += 30 * int(numberOfSecondaryDataAbstractionUnits)
+		} else {
+			Logging.Info("LVSDE embedding started.", nil)
 		}
+	}
 
+	stageTimer.startStage("distances")
+	if resolvedEmbeddingSpecification.DistanceStorage == DataEmbedding.DistanceStorageSparse {
 		if preliminaryToThirtyDimensionsUMAP {
-			dataAbstractionSet.ComputeDistancesBeforeTransformationFromThirtyDimensionalSpaceEuclidean()
-
+			dataAbstractionSet.DistancesBeforeTransformationSource = DataAbstraction.DistanceSourceThirtyDimensionalSpaceEuclidean
+		} else if useCosineDistance {
+			dataAbstractionSet.DistancesBeforeTransformationSource = DataAbstraction.DistanceSourceOriginalSpaceCosine
 		} else {
-			if dataAbstractionSet.DistancesBeforeTransformation == nil {
-				if useCosineDistance {
-					dataAbstractionSet.ComputeDistancesBeforeTransformationCosine()
-				} else {
-					dataAbstractionSet.ComputeDistancesBeforeTransformationEuclidean()
-				}
+			dataAbstractionSet.DistancesBeforeTransformationSource = DataAbstraction.DistanceSourceOriginalSpaceEuclidean
+		}
+	} else if preliminaryToThirtyDimensionsUMAP {
+		err = dataAbstractionSet.ComputeDistancesBeforeTransformationFromThirtyDimensionalSpaceEuclidean(ctx)
+	} else {
+		if dataAbstractionSet.DistancesBeforeTransformation == nil {
+			if useCosineDistance {
+				err = dataAbstractionSet.ComputeDistancesBeforeTransformationCosine(ctx)
+			} else {
+				err = dataAbstractionSet.ComputeDistancesBeforeTransformationEuclidean(ctx)
 			}
 		}
+	}
+	return dataAbstractionSet, preparation, err
+}
 
-		dataEmbeddingTechniqueLVSDE.RandomSeed = 159720256358285954
-		if embeddingSpecification.RandomSeed != "" {
-			var err error
-			dataEmbeddingTechniqueLVSDE.RandomSeed, err = strconv.ParseInt(embeddingSpecification.RandomSeed, 10, 64)
-
-			if err != nil {
-				panic("Not finished successfully. Could not parse the embedding specifications file.")
-			}
-		} else if embeddingSpecification.RandomState != "" {
-			var err error
-			randomState, err = strconv.ParseInt(embeddingSpecification.RandomState, 10, 32)
+func embedPreparedDataAbstractionSet(ctx context.Context, embeddingSpecification EmbeddingSpecification, resolvedEmbeddingSpecification ResolvedEmbeddingSpecification, dataAbstractionSet DataAbstraction.DataAbstractionSet, preparation dataAbstractionSetPreparation, isPreparationShared bool) (knnAccuracies []string, err error) {
+	knnAccuracies = make([]string, 0)
 
-			if err != nil || randomState < 0 {
-				panic("Not finished successfully. Could not parse the embedding specifications file.")
-			}
+	startTime := time.Now()
+	stageTimer := stageTimer{stageTimings: append([]StageTiming(nil), preparation.StageTimings...)}
+	var phaseTimingProgressObserver phaseTimingProgressObserver
+	manifest := newRunManifest(embeddingSpecification, resolvedEmbeddingSpecification, preparation)
+	manifest.IsDataAbstractionSetPreparationShared = isPreparationShared
 
-			randomSeed := dataEmbeddingTechniqueLVSDE.RandomSeed
-			randomSource := rand.NewSource(randomSeed)
-			randomGenerator := rand.New(randomSource)
-			for i := 0; i < int(randomState); i++ {
-				randomSeed = randomGenerator.Int63()
-			}
-		}
+	err = writeRunManifestFile(resolvedEmbeddingSpecification.OutputDirectory, manifest)
+	if err != nil {
+		return nil, err
+	}
 
-		dataEmbeddingTechniqueLVSDE.EmbedData(dataAbstractionSet)
+	defer func() {
+		stageTimer.finishStage()
+		phaseTimingProgressObserver.finishPhase()
+		manifest.StageTimings = stageTimer.stageTimings
+		manifest.LVSDEPhaseTimings = append(manifest.LVSDEPhaseTimings, phaseTimingProgressObserver.phaseTimings...)
+		manifest.finish(err, startTime)
+		writeErr := writeRunManifestFile(resolvedEmbeddingSpecification.OutputDirectory, manifest)
+		if err == nil {
+			err = writeErr
+		}
+	}()
+
+	coloursList := resolvedEmbeddingSpecification.ColoursList
+	classLabels := resolvedEmbeddingSpecification.ClassLabels
+	seeds := resolvedEmbeddingSpecification.Seeds
+	compareWithOtherMethods := resolvedEmbeddingSpecification.CompareWithOtherMethods
+
+	var dataEmbeddingTechniqueLVSDE DataEmbedding.DataEmbeddingTechniqueLVSDE
+	dataEmbeddingTechniqueLVSDE.VisualDensityAdjustmentParameter = resolvedEmbeddingSpecification.VisualDensityAdjustmentParameter
+	dataEmbeddingTechniqueLVSDE.AddProgressObserver(DataEmbedding.LoggingProgressObserver{})
+	dataEmbeddingTechniqueLVSDE.AddProgressObserver(&phaseTimingProgressObserver)
+
+	dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph = resolvedEmbeddingSpecification.EffectiveNumberOfNeighboursForBuildingNeighbourhoodGraph()
+
+	dataEmbeddingTechniqueLVSDE.RandomSeed = seeds.LVSDEInitialisation
+	dataEmbeddingTechniqueLVSDE.PhaseSchedule = resolvedEmbeddingSpecification.PhaseSchedule
+	dataEmbeddingTechniqueLVSDE.CoolingSchedule = DataEmbedding.NewCoolingSchedule(resolvedEmbeddingSpecification.CoolingSchedule)
+	dataEmbeddingTechniqueLVSDE.ConvergenceCriterion = resolvedEmbeddingSpecification.ConvergenceCriterion
+	dataEmbeddingTechniqueLVSDE.RepulsiveForces = resolvedEmbeddingSpecification.RepulsiveForces
+	dataEmbeddingTechniqueLVSDE.Multilevel = resolvedEmbeddingSpecification.Multilevel
+	dataEmbeddingTechniqueLVSDE.NearestNeighbours = resolvedEmbeddingSpecification.NearestNeighbours
+	dataEmbeddingTechniqueLVSDE.DistanceStorage = resolvedEmbeddingSpecification.DistanceStorage
+
+	stageTimer.startStage("lvsde")
+	err = dataEmbeddingTechniqueLVSDE.EmbedData(ctx, dataAbstractionSet)
+	manifest.NumberOfParallelGoroutines = dataEmbeddingTechniqueLVSDE.NumberOfParallelSlices
+	phaseTimingProgressObserver.finishPhase()
+	if errors.Is(err, ErrorHandling.ErrCancelled) && len(dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations) > 0 {
+		embeddingIterations := dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations
+		writeErr := FileReadingOrWriting.WriteJsonFile(filepath.Join(embeddingSpecification.OutputDirectory, "last_completed_iteration.json"), embeddingIterations[len(embeddingIterations)-1])
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		Logging.Warn("Last completed iteration written.", Logging.Fields{"file_path": filepath.Join(embeddingSpecification.OutputDirectory, "last_completed_iteration.json")})
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		fmt.Println("Saving to file...,         time:", time.Now().Format(time.UnixDate), ", timestamp (Unix nanoseconds):", time.Now().UnixMicro())
+	stageTimer.startStage("saving")
+	Logging.Info("Saving to file...", nil)
 
-		embeddingDetails := &dataEmbeddingTechniqueLVSDE.EmbeddingDetails
-		embeddingDetails.ImageWidth = dataAbstractionSet.DataAbstractionUnits[0].ImageWidth
-		embeddingDetails.NumberOfSecondaryDataAbstractionUnits = embeddingSpecification.NumberOfSecondaryDataAbstractionUnits
-		embeddingDetails.RandomSeed = embeddingSpecification.RandomSeed
-		embeddingDetails.PreliminaryToThirtyDimensionsUMAP = embeddingSpecification.PreliminaryToThirtyDimensionsUMAP
-		if embeddingDetails.PreliminaryToThirtyDimensionsUMAP == "" {
-			embeddingDetails.PreliminaryToThirtyDimensionsUMAP = "true"
-		}
-		embeddingDetails.NumberOfNeighboursForBuildingNeighbourhoodGraph = strconv.Itoa(int(dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph))
-		embeddingDetails.NumberOfInitialDataAbstractionUnits = embeddingSpecification.NumberOfInitialDataAbstractionUnits
-		embeddingDetails.VisualDensityAdjustmentParameter = embeddingSpecification.VisualDensityAdjustmentParameter
-		if embeddingDetails.VisualDensityAdjustmentParameter == "" {
-			embeddingDetails.VisualDensityAdjustmentParameter = "0.9"
+	embeddingDetails := &dataEmbeddingTechniqueLVSDE.EmbeddingDetails
+	embeddingDetails.ImageWidth = dataAbstractionSet.DataAbstractionUnits[0].ImageWidth
+	embeddingDetails.NumberOfSecondaryDataAbstractionUnits = embeddingSpecification.NumberOfSecondaryDataAbstractionUnits
+	embeddingDetails.RandomSeed = embeddingSpecification.RandomSeed
+	embeddingDetails.PreliminaryToThirtyDimensionsUMAP = embeddingSpecification.PreliminaryToThirtyDimensionsUMAP
+	if embeddingDetails.PreliminaryToThirtyDimensionsUMAP == "" {
+		embeddingDetails.PreliminaryToThirtyDimensionsUMAP = "true"
+	}
+	embeddingDetails.NumberOfNeighboursForBuildingNeighbourhoodGraph = strconv.Itoa(int(dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph))
+	embeddingDetails.NumberOfInitialDataAbstractionUnits = embeddingSpecification.NumberOfInitialDataAbstractionUnits
+	embeddingDetails.VisualDensityAdjustmentParameter = embeddingSpecification.VisualDensityAdjustmentParameter
+	if embeddingDetails.VisualDensityAdjustmentParameter == "" {
+		embeddingDetails.VisualDensityAdjustmentParameter = "0.9"
+	}
+	embeddingDetails.ClassLabels = embeddingSpecification.ClassLabels
+	embeddingDetails.ColoursList = coloursList
+	embeddingDetails.RandomState = embeddingSpecification.RandomState
+	embeddingDetails.MasterSeed = embeddingSpecification.MasterSeed
+	embeddingDetails.OriginalDataAbstractionUnitNumbers = preparation.OriginalDataAbstractionUnitNumbers
+	embeddingDetails.EvaluationNeighbourhoodSizes = embeddingSpecification.EvaluationNeighbourhoodSizes
+
+	if embeddingSpecification.ImagesFileGrayscaleSingleChannel != "" {
+		embeddingDetails.ImagesGrayscaleSingleChannel = make([][]uint8, len(dataAbstractionSet.DataAbstractionUnits))
+		for i := 0; i < len(dataAbstractionSet.DataAbstractionUnits); i++ {
+			embeddingDetails.ImagesGrayscaleSingleChannel[i] = dataAbstractionSet.DataAbstractionUnits[i].ImageGrayscale
 		}
-		embeddingDetails.ClassLabels = embeddingSpecification.ClassLabels
-		embeddingDetails.ColoursList = coloursList
-		embeddingDetails.RandomState = embeddingSpecification.RandomState
-		embeddingDetails.EvaluationNeighbourhoodSizes = embeddingSpecification.EvaluationNeighbourhoodSizes
+	}
 
-		if embeddingSpecification.ImagesFileGrayscaleSingleChannel != "" {
-			embeddingDetails.ImagesGrayscaleSingleChannel = make([][]uint8, len(dataAbstractionSet.DataAbstractionUnits))
-			for i := 0; i < len(dataAbstractionSet.DataAbstractionUnits); i++ {
-				embeddingDetails.ImagesGrayscaleSingleChannel[i] = dataAbstractionSet.DataAbstractionUnits[i].ImageGrayscale
-			}
+	if embeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
+		embeddingDetails.ImagesRedGreenBlueChannels = make([][]uint8, len(dataAbstractionSet.DataAbstractionUnits))
+		for i := 0; i < len(dataAbstractionSet.DataAbstractionUnits); i++ {
+			embeddingDetails.ImagesRedGreenBlueChannels[i] = dataAbstractionSet.DataAbstractionUnits[i].ImageRGB
 		}
+	}
 
-		if embeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
-			embeddingDetails.ImagesRedGreenBlueChannels = make([][]uint8, len(dataAbstractionSet.DataAbstractionUnits))
-			for i := 0; i < len(dataAbstractionSet.DataAbstractionUnits); i++ {
-				embeddingDetails.ImagesRedGreenBlueChannels[i] = dataAbstractionSet.DataAbstractionUnits[i].ImageRGB
-			}
-		}
+	embeddingDetails.VersionOfUsedChocolateLVSDE = DataEmbedding.VersionOfChocolateLVSDE
 
-		embeddingDetails.VersionOfUsedChocolateLVSDE = "1.20"
+	lastIteration := len(embeddingDetails.EmbeddingIterations) - 1
 
-		lastIteration := 1829
+	hasImages := resolvedEmbeddingSpecification.HasImagesFile()
 
-		FileReadingOrWriting.WriteEmbeddingToFile(embeddingDetails.EmbeddingIterations[lastIteration], filepath.Join(embeddingSpecification.OutputDirectory, "last_iteration_colouring_0.png"), 0, &dataAbstractionSet, coloursList)
-		FileReadingOrWriting.WriteEmbeddingToFile(embeddingDetails.EmbeddingIterations[lastIteration], filepath.Join(embeddingSpecification.OutputDirectory, "last_iteration_colouring_1.png"), 1, &dataAbstractionSet, coloursList)
-		FileReadingOrWriting.WriteEmbeddingToFile(embeddingDetails.EmbeddingIterations[lastIteration], filepath.Join(embeddingSpecification.OutputDirectory, "last_iteration_colouring_2.png"), 2, &dataAbstractionSet, coloursList)
-		if embeddingSpecification.ImagesFileGrayscaleSingleChannel != "" || embeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
-			FileReadingOrWriting.WriteEmbeddingToFile(embeddingDetails.EmbeddingIterations[lastIteration], filepath.Join(embeddingSpecification.OutputDirectory, "last_iteration_colouring_3.png"), 3, &dataAbstractionSet, coloursList)
-			FileReadingOrWriting.WriteEmbeddingToFile(embeddingDetails.EmbeddingIterations[lastIteration], filepath.Join(embeddingSpecification.OutputDirectory, "last_iteration_colouring_4.png"), 4, &dataAbstractionSet, coloursList)
-		}
+	err = writeColouringImages(embeddingDetails.EmbeddingIterations[lastIteration], embeddingSpecification.OutputDirectory, "last_iteration_colouring_", hasImages, &dataAbstractionSet, coloursList, seeds.RenderShuffling)
+	if err != nil {
+		return nil, err
+	}
 
-		jsonBytes, _ := json.MarshalIndent(embeddingDetails.EmbeddingIterations[lastIteration], "", "\t")
-		ioutil.WriteFile(filepath.Join(embeddingSpecification.OutputDirectory, "last_iteration.json"), jsonBytes, FileReadingOrWriting.Chmod)
+	err = FileReadingOrWriting.WriteJsonFile(filepath.Join(embeddingSpecification.OutputDirectory, "last_iteration.json"), embeddingDetails.EmbeddingIterations[lastIteration])
+	if err != nil {
+		return nil, err
+	}
 
-		jsonBytes, _ = json.MarshalIndent(embeddingDetails.EmbeddingIterations, "", "\t")
-		var zipFile *os.File
-		zipFile, err = os.Create(filepath.Join(embeddingSpecification.OutputDirectory, "iterations.json.zip"))
-		if err != nil {
-			panic("Not finished successfully.")
-		}
-		zipWriter := zip.NewWriter(zipFile)
-		var writer io.Writer
-		writer, err = zipWriter.Create("iterations.json")
-		if err != nil {
-			panic("Not finished successfully.")
-		}
-		writer.Write(jsonBytes)
-		zipWriter.Close()
-		zipFile.Close()
+	jsonBytes, _ := json.MarshalIndent(embeddingDetails.EmbeddingIterations, "", "\t")
+	err = FileReadingOrWriting.WriteZipFile(filepath.Join(embeddingSpecification.OutputDirectory, "iterations.json.zip"), "iterations.json", jsonBytes)
+	if err != nil {
+		return nil, err
+	}
 
-		bsonBytes, _ := bson.Marshal(embeddingDetails)
-		zipFile, err = os.Create(filepath.Join(embeddingSpecification.OutputDirectory, "embedding.archive"))
-		if err != nil {
-			panic("Not finished successfully.")
-		}
-		zipWriter = zip.NewWriter(zipFile)
-		writer, err = zipWriter.Create("archive.bson")
-		if err != nil {
-			panic("Not finished successfully.")
-		}
-		writer.Write(bsonBytes)
-		zipWriter.Close()
-		zipFile.Close()
+	err = FileReadingOrWriting.WriteEmbeddingArchive(filepath.Join(embeddingSpecification.OutputDirectory, "embedding.archive"), embeddingDetails)
+	if err != nil {
+		return nil, err
+	}
 
-		bsonBytes, _ = bson.Marshal(embeddingDetails.ToEmbeddedData())
-		zipFile, err = os.Create(filepath.Join(embeddingSpecification.OutputDirectory, "embedded_data.VCED"))
-		if err != nil {
-			panic("Not finished successfully.")
-		}
-		zipWriter = zip.NewWriter(zipFile)
-		writer, err = zipWriter.Create("embedded_data.VCED.uncompressed")
-		if err != nil {
-			panic("Not finished successfully.")
-		}
-		writer.Write(bsonBytes)
-		zipWriter.Close()
-		zipFile.Close()
+	err = FileReadingOrWriting.WriteEmbeddedDataFile(filepath.Join(embeddingSpecification.OutputDirectory, "embedded_data.VCED"), embeddingDetails.ToEmbeddedData())
+	if err != nil {
+		return nil, err
+	}
 
-		FileReadingOrWriting.WriteLegendFileHtml(filepath.Join(embeddingSpecification.OutputDirectory, "legend.html"), classLabels, coloursList)
-		FileReadingOrWriting.WriteShowFileHtml(embeddingSpecification.OutputDirectory, embeddingDetails.EmbeddingIterations[lastIteration])
+	err = FileReadingOrWriting.WriteLegendFileHtml(filepath.Join(embeddingSpecification.OutputDirectory, "legend.html"), classLabels, coloursList)
+	if err != nil {
+		return nil, err
+	}
 
-		fmt.Println("Embedding and saving to file finished on", time.Now().Format(time.UnixDate), ", timestamp (Unix nanoseconds):", time.Now().UnixMicro())
+	err = FileReadingOrWriting.WriteShowFileHtml(embeddingSpecification.OutputDirectory, embeddingDetails.EmbeddingIterations[lastIteration])
+	if err != nil {
+		return nil, err
+	}
 
-		if len(embeddingSpecification.EvaluationNeighbourhoodSizes) > 0 || compareWithOtherMethods {
-			fmt.Println("Please wait...")
-		}
+	Logging.Info("Embedding and saving to file finished.", nil)
 
-		var embeddingCompare1 []*DataAbstraction.DataAbstractionUnitVisibility
-		var embeddingCompare2 []*DataAbstraction.DataAbstractionUnitVisibility
+	var embeddingCompare1 []*DataAbstraction.DataAbstractionUnitVisibility
+	var embeddingCompare2 []*DataAbstraction.DataAbstractionUnitVisibility
 
-		if compareWithOtherMethods {
+	if compareWithOtherMethods {
+		stageTimer.startStage("comparison")
+		Logging.Info("Saving comparison embeddings...", nil)
 
-			os.MkdirAll(filepath.Join(embeddingSpecification.OutputDirectory, "compare"), FileReadingOrWriting.Chmod)
-			os.MkdirAll(filepath.Join(embeddingSpecification.OutputDirectory, "compare", "UMAP embedding"), FileReadingOrWriting.Chmod)
-			os.MkdirAll(filepath.Join(embeddingSpecification.OutputDirectory, "compare", "t-SNE (Barnes Hut variant) embedding"), FileReadingOrWriting.Chmod)
+		embeddingCompare1 = make([]*DataAbstraction.DataAbstractionUnitVisibility, len(dataAbstractionSet.DataAbstractionUnits))
 
-			embeddingCompare1 = make([]*DataAbstraction.DataAbstractionUnitVisibility, len(dataAbstractionSet.DataAbstractionUnits))
+		for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
+			dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
+			dataAbstractionUnit.AreAllVisualSpaceProjectionsInRedLayer = true
+			embeddingCompare1[j] = dataAbstractionUnit.ToDataAbstractionUnitVisibility(1, "UMAP")
+		}
 
-			for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
-				dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
-				dataAbstractionUnit.AreAllVisualSpaceProjectionsInRedLayer = true
-				embeddingCompare1[j] = dataAbstractionUnit.ToDataAbstractionUnitVisibility(1, "UMAP")
-			}
+		err = writeComparisonEmbedding(filepath.Join(embeddingSpecification.OutputDirectory, "compare", "UMAP embedding"), "UMAP", embeddingCompare1, embeddingDetails, hasImages, &dataAbstractionSet, coloursList, seeds.RenderShuffling)
+		if err != nil {
+			return nil, err
+		}
 
-			FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare1, filepath.Join(embeddingSpecification.OutputDirectory, "compare", "UMAP embedding", "colouring_0.png"), 0, &dataAbstractionSet, coloursList)
-			FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare1, filepath.Join(embeddingSpecification.OutputDirectory, "compare", "UMAP embedding", "colouring_1.png"), 1, &dataAbstractionSet, coloursList)
-			FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare1, filepath.Join(embeddingSpecification.OutputDirectory, "compare", "UMAP embedding", "colouring_2.png"), 2, &dataAbstractionSet, coloursList)
-			if embeddingSpecification.ImagesFileGrayscaleSingleChannel != "" || embeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
-				FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare1, filepath.Join(embeddingSpecification.OutputDirectory, "compare", "UMAP embedding", "colouring_3.png"), 3, &dataAbstractionSet, coloursList)
-				FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare1, filepath.Join(embeddingSpecification.OutputDirectory, "compare", "UMAP embedding", "colouring_4.png"), 4, &dataAbstractionSet, coloursList)
-			}
+		embeddingCompare2 = make([]*DataAbstraction.DataAbstractionUnitVisibility, len(dataAbstractionSet.DataAbstractionUnits))
 
-			jsonBytes, _ = json.MarshalIndent(embeddingCompare1, "", "\t")
-			ioutil.WriteFile(filepath.Join(embeddingSpecification.OutputDirectory, "compare", "UMAP embedding", "compare_embedding.json"), jsonBytes, FileReadingOrWriting.Chmod)
+		for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
+			dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
+			dataAbstractionUnit.AreAllVisualSpaceProjectionsInRedLayer = true
+			embeddingCompare2[j] = dataAbstractionUnit.ToDataAbstractionUnitVisibility(1, "t-SNE")
+		}
 
-			bsonBytes, _ = bson.Marshal(DataAbstraction.EmbeddedDataFromCompareEmbedding("UMAP", embeddingCompare1, embeddingDetails))
-			zipFile, err = os.Create(filepath.Join(embeddingSpecification.OutputDirectory, "compare", "UMAP embedding", "embedded_data.VCED"))
-			if err != nil {
-				panic("Not finished successfully.")
-			}
-			zipWriter = zip.NewWriter(zipFile)
-			writer, err = zipWriter.Create("embedded_data.VCED.uncompressed")
-			if err != nil {
-				panic("Not finished successfully.")
-			}
-			writer.Write(bsonBytes)
-			zipWriter.Close()
-			zipFile.Close()
+		err = writeComparisonEmbedding(filepath.Join(embeddingSpecification.OutputDirectory, "compare", "t-SNE (Barnes Hut variant) embedding"), "t-SNE (Barnes Hut variant)", embeddingCompare2, embeddingDetails, hasImages, &dataAbstractionSet, coloursList, seeds.RenderShuffling)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-			embeddingCompare2 = make([]*DataAbstraction.DataAbstractionUnitVisibility, len(dataAbstractionSet.DataAbstractionUnits))
+	if len(embeddingSpecification.EvaluationNeighbourhoodSizes) > 0 {
+		stageTimer.startStage("evaluation")
+		Logging.Info("Evaluating the embedding...", nil)
+		report := strings.Builder{}
+		confusionMatrices := strings.Builder{}
 
-			for j := 0; j < len(dataAbstractionSet.DataAbstractionUnits); j++ {
-				dataAbstractionUnit := &dataAbstractionSet.DataAbstractionUnits[j]
-				dataAbstractionUnit.AreAllVisualSpaceProjectionsInRedLayer = true
-				embeddingCompare2[j] = dataAbstractionUnit.ToDataAbstractionUnitVisibility(1, "t-SNE")
-			}
+		report.WriteString("Statistical_evaluation_type, Evaluation_neighbourhood_size, Embedding technique, Percent (rounded to 3 decimal places), Correct, Incorrects\r\n")
 
-			FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare2, filepath.Join(embeddingSpecification.OutputDirectory, "compare", "t-SNE (Barnes Hut variant) embedding", "colouring_0.png"), 0, &dataAbstractionSet, coloursList)
-			FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare2, filepath.Join(embeddingSpecification.OutputDirectory, "compare", "t-SNE (Barnes Hut variant) embedding", "colouring_1.png"), 1, &dataAbstractionSet, coloursList)
-			FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare2, filepath.Join(embeddingSpecification.OutputDirectory, "compare", "t-SNE (Barnes Hut variant) embedding", "colouring_2.png"), 2, &dataAbstractionSet, coloursList)
-			if embeddingSpecification.ImagesFileGrayscaleSingleChannel != "" || embeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
-				FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare2, filepath.Join(embeddingSpecification.OutputDirectory, "compare", "t-SNE (Barnes Hut variant) embedding", "colouring_3.png"), 3, &dataAbstractionSet, coloursList)
-				FileReadingOrWriting.WriteEmbeddingToFile(embeddingCompare2, filepath.Join(embeddingSpecification.OutputDirectory, "compare, \"t-SNE (Barnes Hut variant) embedding\"", "colouring_4.png"), 4, &dataAbstractionSet, coloursList)
+		for _, k := range resolvedEmbeddingSpecification.EvaluationNeighbourhoodSizes {
+			knnAccuracy, err := DataEmbedding.EvaluateEmbeddingForReport(embeddingDetails.EmbeddingIterations[lastIteration], k, "LVSDE", &report, &confusionMatrices, seeds.EvaluationTieBreaking)
+			if err != nil {
+				return knnAccuracies, err
 			}
-
-			jsonBytes, _ = json.MarshalIndent(embeddingCompare2, "", "\t")
-			ioutil.WriteFile(filepath.Join(embeddingSpecification.OutputDirectory, "compare", "t-SNE (Barnes Hut variant) embedding", "compare_embedding.json"), jsonBytes, FileReadingOrWriting.Chmod)
-
-			bsonBytes, _ = bson.Marshal(DataAbstraction.EmbeddedDataFromCompareEmbedding("t-SNE (Barnes Hut variant)", embeddingCompare2, embeddingDetails))
-			zipFile, err = os.Create(filepath.Join(embeddingSpecification.OutputDirectory, "compare", "t-SNE (Barnes Hut variant) embedding", "embedded_data.VCED"))
+			knnAccuracies = append(knnAccuracies, "k="+strconv.Itoa(k)+": "+knnAccuracy)
+			_, err = DataEmbedding.EvaluateEmbeddingForReport(embeddingCompare1, k, "UMAP", &report, &confusionMatrices, seeds.EvaluationTieBreaking)
 			if err != nil {
-				panic("Not finished successfully.")
+				return knnAccuracies, err
 			}
-			zipWriter = zip.NewWriter(zipFile)
-			writer, err = zipWriter.Create("embedded_data.VCED.uncompressed")
+			_, err = DataEmbedding.EvaluateEmbeddingForReport(embeddingCompare2, k, "t-SNE (Barnes Hut variant)", &report, &confusionMatrices, seeds.EvaluationTieBreaking)
 			if err != nil {
-				panic("Not finished successfully.")
+				return knnAccuracies, err
 			}
-			writer.Write(bsonBytes)
-			zipWriter.Close()
-			zipFile.Close()
 		}
 
-		if len(embeddingSpecification.EvaluationNeighbourhoodSizes) > 0 {
-			report := strings.Builder{}
-			confusionMatrices := strings.Builder{}
+		err = FileReadingOrWriting.WriteFile(filepath.Join(embeddingSpecification.OutputDirectory, "report.csv"), []byte(report.String()))
+		if err != nil {
+			return knnAccuracies, err
+		}
 
-			report.WriteString("Statistical_evaluation_type, Evaluation_neighbourhood_size, Embedding technique, Percent (rounded to 3 decimal places), Correct, Incorrects\r\n")
+		err = FileReadingOrWriting.WriteFile(filepath.Join(embeddingSpecification.OutputDirectory, "confusionMatrices.txt"), []byte(confusionMatrices.String()))
+		if err != nil {
+			return knnAccuracies, err
+		}
+	}
 
-			for _, evaluationNeighbourhoodSize := range embeddingSpecification.EvaluationNeighbourhoodSizes {
-				k, err := strconv.Atoi(evaluationNeighbourhoodSize)
-				if err != nil {
-					panic("Not finished successfully.")
-				}
+	Logging.Info("Finished processing the embedding specification.", Logging.Fields{"knn_accuracies": strings.Join(knnAccuracies, "; ")})
 
-				evaluation := DataEmbedding.EvaluateEmbedding(
-					embeddingDetails.EmbeddingIterations[lastIteration], k,
-					[]string{"red", "gray"}, []string{"red", "gray"}, 3)
-				report.WriteString("KNN_accuracy_(red_and_gray)_(red_and_gray)," + evaluationNeighbourhoodSize + ",LVSDE," +
-					evaluation[0] + "\r\n")
-				confusionMatrices.WriteString("Evaluation layers:(red_and_gray)\r\nClassification layers: (red_and_gray)\r\nEvaluation neighbourhood size: " + evaluationNeighbourhoodSize + "\r\nEmbedding technique: LVSDE\r\nConfusion matrix:\r\n" +
-					evaluation[1] + "______________________\r\n")
-
-				evaluation = DataEmbedding.EvaluateEmbedding(
-					embeddingDetails.EmbeddingIterations[lastIteration], k,
-					[]string{"red", "gray"}, []string{"red"}, 3)
-
-				report.WriteString("KNN_accuracy_(red_and_gray)_(red)," + evaluationNeighbourhoodSize + ",LVSDE," +
-					evaluation[0] + "\r\n")
-				confusionMatrices.WriteString("Evaluation layers: (red_and_gray)\r\nClassification layers: (red)\r\nEvaluation neighbourhood size: " + evaluationNeighbourhoodSize + "\r\nEmbedding technique: LVSDE\r\nConfusion matrix:\r\n" +
-					evaluation[1] + "______________________\r\n")
-
-				evaluation = DataEmbedding.EvaluateEmbedding(
-					embeddingDetails.EmbeddingIterations[lastIteration], k,
-					[]string{"red"}, []string{"red"}, 3)
-
-				report.WriteString("KNN_accuracy_(red)_(red)," + evaluationNeighbourhoodSize + ",LVSDE," +
-					evaluation[0] + "\r\n")
-				confusionMatrices.WriteString("Evaluation layers: (red)\r\nClassification layers: (red)\r\nEvaluation neighbourhood size: " + evaluationNeighbourhoodSize + "\r\nEmbedding technique: LVSDE\r\nConfusion matrix:\r\n" +
-					evaluation[1] + "______________________\r\n")
-
-				evaluation = DataEmbedding.EvaluateEmbedding(
-					embeddingDetails.EmbeddingIterations[lastIteration], k,
-					[]string{"gray"}, []string{"gray"}, 3)
-
-				report.WriteString("KNN_accuracy_(gray)_(gray)," + evaluationNeighbourhoodSize + ",LVSDE," +
-					evaluation[0] + "\r\n")
-				confusionMatrices.WriteString("Evaluation layers: (gray)\r\nClassification layers: (gray)\r\nEvaluation neighbourhood size: " + evaluationNeighbourhoodSize + "\r\nEmbedding technique: LVSDE\r\nConfusion matrix:\r\n" +
-					evaluation[1] + "______________________\r\n")
-
-				evaluation = DataEmbedding.EvaluateEmbedding(
-					embeddingDetails.EmbeddingIterations[lastIteration], k,
-					[]string{"gray"}, []string{"red"}, 3)
-
-				report.WriteString("KNN_accuracy_(gray)_(red)," + evaluationNeighbourhoodSize + ",LVSDE," +
-					evaluation[0] + "\r\n")
-				confusionMatrices.WriteString("Evaluation layers: (gray)\r\nClassification layers: (red)\r\nEvaluation neighbourhood size: " + evaluationNeighbourhoodSize + "\r\nEmbedding technique: LVSDE\r\nConfusion matrix:\r\n" +
-					evaluation[1] + "______________________\r\n")
-
-				evaluation = DataEmbedding.EvaluateEmbedding(
-					embeddingDetails.EmbeddingIterations[lastIteration], k,
-					[]string{"gray"}, []string{"red", "gray"}, 3)
-
-				report.WriteString("KNN_accuracy_(gray)_(red_and_gray)," + evaluationNeighbourhoodSize + ",LVSDE," +
-					evaluation[0] + "\r\n")
-				confusionMatrices.WriteString("Evaluation layers: (gray)\r\nClassification layers: (red_and_gray)\r\nEvaluation neighbourhood size: " + evaluationNeighbourhoodSize + "\r\nEmbedding technique: LVSDE\r\nConfusion matrix:\r\n" +
-					evaluation[1] + "______________________\r\n")
-
-				report.WriteString("#, #, #, #\r\n")
-
-				evaluation = DataEmbedding.EvaluateEmbedding(
-					embeddingCompare1, k,
-					[]string{"NA"}, []string{"NA"}, 3)
-				report.WriteString("KNN_accuracy," + evaluationNeighbourhoodSize + ",UMAP," +
-					evaluation[0] + "\r\n")
-				confusionMatrices.WriteString("Evaluation layers: NA\r\nClassification layers: NA\r\nEvaluation neighbourhood size: " + evaluationNeighbourhoodSize + "\r\nEmbedding technique: UMAP\r\nConfusion matrix:\r\n" +
-					evaluation[1] + "______________________\r\n")
-
-				report.WriteString("#, #, #, #\r\n")
-
-				evaluation = DataEmbedding.EvaluateEmbedding(
-					embeddingCompare2, k,
-					[]string{"NA"}, []string{"NA"}, 3)
-
-				report.WriteString("KNN_accuracy," + evaluationNeighbourhoodSize + ", t-SNE (Barnes Hut variant)," +
-					evaluation[0] + "\r\n")
-				confusionMatrices.WriteString("Evaluation layers: NA\r\nClassification layers: NA\r\nEvaluation neighbourhood size: " + evaluationNeighbourhoodSize + "\r\nEmbedding technique: t-SNE (Barnes Hut variant)\r\nConfusion matrix:\r\n" +
-					evaluation[1] + "______________________\r\n")
-
-				report.WriteString("#, #, #, #\r\n")
-			}
+	return knnAccuracies, nil
+}
 
-			err = ioutil.WriteFile(filepath.Join(embeddingSpecification.OutputDirectory, "report.csv"), []byte(report.String()), FileReadingOrWriting.Chmod)
-			if err != nil {
-				panic("Not finished successfully.")
-			}
+func writeColouringImages(dataAbstractionUnitVisibilities []*DataAbstraction.DataAbstractionUnitVisibility, directory string, fileNamePrefix string, hasImages bool, dataAbstractionSet *DataAbstraction.DataAbstractionSet, coloursList []string, shufflingRandomSeed int64) error {
+	var numberOfColourings int32 = 3
+	if hasImages {
+		numberOfColourings = 5
+	}
 
-			err = ioutil.WriteFile(filepath.Join(embeddingSpecification.OutputDirectory, "confusionMatrices.txt"), []byte(confusionMatrices.String()), FileReadingOrWriting.Chmod)
-			if err != nil {
-				panic("Not finished successfully.")
-			}
+	var colouring int32
+	for colouring = 0; colouring < numberOfColourings; colouring++ {
+		filePath := filepath.Join(directory, fileNamePrefix+strconv.Itoa(int(colouring))+".png")
+		err := FileReadingOrWriting.WriteEmbeddingToFile(dataAbstractionUnitVisibilities, filePath, colouring, dataAbstractionSet, coloursList, shufflingRandomSeed)
+		if err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
 
-		if len(embeddingSpecification.EvaluationNeighbourhoodSizes) > 0 || compareWithOtherMethods {
-			fmt.Println("Finished processing the embedding specification.")
+func writeComparisonEmbedding(directory string, compareEmbeddingMethodName string, embeddingCompare []*DataAbstraction.DataAbstractionUnitVisibility, mainEmbeddingDetails *DataAbstraction.EmbeddingDetails, hasImages bool, dataAbstractionSet *DataAbstraction.DataAbstractionSet, coloursList []string, shufflingRandomSeed int64) error {
+	err := os.MkdirAll(directory, FileReadingOrWriting.Chmod)
+	if err != nil {
+		return ErrorHandling.NewFileError(directory, ErrorHandling.ErrFileWrite, err.Error())
+	}
+
+	err = writeColouringImages(embeddingCompare, directory, "colouring_", hasImages, dataAbstractionSet, coloursList, shufflingRandomSeed)
+	if err != nil {
+		return err
+	}
+
+	err = FileReadingOrWriting.WriteJsonFile(filepath.Join(directory, "compare_embedding.json"), embeddingCompare)
+	if err != nil {
+		return err
+	}
+
+	return FileReadingOrWriting.WriteEmbeddedDataFile(filepath.Join(directory, "embedded_data.VCED"), DataAbstraction.EmbeddedDataFromCompareEmbedding(compareEmbeddingMethodName, embeddingCompare, mainEmbeddingDetails))
+}
+
+func FormatRunSummaryTable(runSummaries []EmbeddingSpecificationRunSummary) string {
+	table := strings.Builder{}
+	table.WriteString(fmt.Sprintf("%-6s %-40s %-30s %-14s %s\n", "Index", "Output directory", "Status", "Wall time", "KNN accuracy (red_and_gray)_(red_and_gray)"))
+	for _, runSummary := range runSummaries {
+		knnAccuracies := "not evaluated"
+		if len(runSummary.KNNAccuracies) > 0 {
+			knnAccuracies = strings.Join(runSummary.KNNAccuracies, "; ")
 		}
+		table.WriteString(fmt.Sprintf("%-6d %-40s %-30s %-14s %s\n", runSummary.SpecificationIndex, runSummary.OutputDirectory, runSummary.Status, runSummary.WallTime.Round(time.Millisecond).String(), knnAccuracies))
 	}
+	return table.String()
+}
+
+func WriteRunSummaryFile(filePath string, runSummaries []EmbeddingSpecificationRunSummary) error {
+	summary := strings.Builder{}
+	summary.WriteString("Specification_index,Output_directory,Status,Wall_time_seconds,KNN_accuracy_(red_and_gray)_(red_and_gray)\r\n")
+	for _, runSummary := range runSummaries {
+		summary.WriteString(strconv.Itoa(runSummary.SpecificationIndex) + "," +
+			strconv.Quote(runSummary.OutputDirectory) + "," +
+			strconv.Quote(runSummary.Status) + "," +
+			strconv.FormatFloat(runSummary.WallTime.Seconds(), 'f', 3, 64) + "," +
+			strconv.Quote(strings.Join(runSummary.KNNAccuracies, "; ")) + "\r\n")
+	}
+
+	return FileReadingOrWriting.WriteFile(filePath, []byte(summary.String()))
 }
@@ -0,0 +1,185 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const FieldOverrideEnvironmentVariablePrefix = "LVSDE_"
+
+type FieldOverride struct {
+	SpecificationIndex int    `json:"specification_index"`
+	FieldName          string `json:"field_name"`
+	Value              string `json:"value"`
+	Source             string `json:"source"`
+}
+
+func ParseFieldOverride(argument string) (FieldOverride, error) {
+	fieldOverride := FieldOverride{SpecificationIndex: -1, Source: "command line"}
+
+	separatorIndex := strings.Index(argument, "=")
+	if separatorIndex <= 0 {
+		return fieldOverride, fmt.Errorf("%q is not of the form [index:]field=value", argument)
+	}
+	fieldOverride.FieldName = strings.TrimSpace(argument[:separatorIndex])
+	fieldOverride.Value = argument[separatorIndex+1:]
+
+	if indexSeparatorIndex := strings.Index(fieldOverride.FieldName, ":"); indexSeparatorIndex >= 0 {
+		specificationIndex, err := strconv.Atoi(fieldOverride.FieldName[:indexSeparatorIndex])
+		if err != nil || specificationIndex < 0 {
+			return fieldOverride, fmt.Errorf("%q does not start with a valid embedding specification index", argument)
+		}
+		fieldOverride.SpecificationIndex = specificationIndex
+		fieldOverride.FieldName = fieldOverride.FieldName[indexSeparatorIndex+1:]
+	}
+
+	if fieldOverride.FieldName == "" {
+		return fieldOverride, fmt.Errorf("%q has no field name", argument)
+	}
+	return fieldOverride, nil
+}
+
+func FieldOverridesFromEnvironment(environment []string) ([]FieldOverride, []string) {
+	fieldOverrides := make([]FieldOverride, 0)
+	unknownVariableNames := make([]string, 0)
+
+	for _, variable := range environment {
+		separatorIndex := strings.Index(variable, "=")
+		if separatorIndex < 0 || !strings.HasPrefix(variable[:separatorIndex], FieldOverrideEnvironmentVariablePrefix) {
+			continue
+		}
+		variableName := variable[:separatorIndex]
+		fieldName := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(variableName, FieldOverrideEnvironmentVariablePrefix), "__", "."))
+
+		if _, err := overridableField(reflect.ValueOf(&EmbeddingSpecification{}).Elem(), fieldName); err != nil {
+			unknownVariableNames = append(unknownVariableNames, variableName)
+			continue
+		}
+		fieldOverrides = append(fieldOverrides, FieldOverride{SpecificationIndex: -1, FieldName: fieldName, Value: variable[separatorIndex+1:], Source: "environment variable " + variableName})
+	}
+
+	sort.Slice(fieldOverrides, func(i, j int) bool {
+		return fieldOverrides[i].FieldName < fieldOverrides[j].FieldName
+	})
+	return fieldOverrides, unknownVariableNames
+}
+
+func ApplyFieldOverrides(embeddingSpecifications *EmbeddingSpecifications, fieldOverrides []FieldOverride) []ValidationProblem {
+	problems := make([]ValidationProblem, 0)
+
+	for _, fieldOverride := range fieldOverrides {
+		if fieldOverride.SpecificationIndex >= len(embeddingSpecifications.EmbeddingSpecifications) {
+			problems = append(problems, ValidationProblem{SpecificationIndex: -1, FieldName: fieldOverride.FieldName, Message: fmt.Sprintf("cannot be overridden by the %s because there is no embedding specification %d", fieldOverride.Source, fieldOverride.SpecificationIndex), Err: ErrorHandling.ErrInvalidInput})
+			continue
+		}
+
+		for i := range embeddingSpecifications.EmbeddingSpecifications {
+			if fieldOverride.SpecificationIndex != -1 && fieldOverride.SpecificationIndex != i {
+				continue
+			}
+
+			embeddingSpecification := &embeddingSpecifications.EmbeddingSpecifications[i]
+			err := applyFieldOverride(embeddingSpecification, fieldOverride)
+			if err != nil {
+				problems = append(problems, ValidationProblem{SpecificationIndex: i, FieldName: fieldOverride.FieldName, Message: fmt.Sprintf("cannot be overridden by the %s: %s", fieldOverride.Source, err.Error()), Err: ErrorHandling.ErrInvalidInput})
+				break
+			}
+
+			appliedFieldOverride := fieldOverride
+			appliedFieldOverride.SpecificationIndex = i
+			embeddingSpecification.FieldOverrides = append(embeddingSpecification.FieldOverrides, appliedFieldOverride)
+		}
+	}
+
+	return problems
+}
+
+func applyFieldOverride(embeddingSpecification *EmbeddingSpecification, fieldOverride FieldOverride) error {
+	field, err := overridableField(reflect.ValueOf(embeddingSpecification).Elem(), fieldOverride.FieldName)
+	if err != nil {
+		return err
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(fieldOverride.Value)
+	case reflect.Slice:
+		values := make([]string, 0)
+		if strings.TrimSpace(fieldOverride.Value) != "" {
+			for _, value := range strings.Split(fieldOverride.Value, ",") {
+				values = append(values, strings.TrimSpace(value))
+			}
+		}
+		field.Set(reflect.ValueOf(values))
+	}
+	return nil
+}
+
+func overridableField(object reflect.Value, fieldName string) (reflect.Value, error) {
+	fieldNameParts := strings.SplitN(fieldName, ".", 2)
+
+	objectType := object.Type()
+	fieldNames := make([]string, 0, objectType.NumField())
+	for i := 0; i < objectType.NumField(); i++ {
+		jsonFieldName := strings.Split(objectType.Field(i).Tag.Get("json"), ",")[0]
+		if jsonFieldName == "" || jsonFieldName == "-" {
+			continue
+		}
+		fieldNames = append(fieldNames, jsonFieldName)
+		if jsonFieldName != fieldNameParts[0] {
+			continue
+		}
+
+		field := object.Field(i)
+		switch {
+		case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct:
+			if len(fieldNameParts) == 1 {
+				return reflect.Value{}, fmt.Errorf("is an object, override one of its fields such as %s.%s instead", jsonFieldName, strings.Split(field.Type().Elem().Field(0).Tag.Get("json"), ",")[0])
+			}
+			nestedObject := field
+			if field.IsNil() {
+				nestedObject = reflect.New(field.Type().Elem())
+			}
+			nestedField, err := overridableField(nestedObject.Elem(), fieldNameParts[1])
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if field.IsNil() {
+				field.Set(nestedObject)
+			}
+			return nestedField, nil
+		case len(fieldNameParts) > 1:
+			return reflect.Value{}, fmt.Errorf("%s is not an object", jsonFieldName)
+		case field.Kind() == reflect.String || (field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String):
+			return field, nil
+		}
+		return reflect.Value{}, fmt.Errorf("cannot be overridden")
+	}
+
+	message := "is not a known field"
+	if suggestion := closestFieldName(fieldNameParts[0], fieldNames); suggestion != "" {
+		message += ", did you mean \"" + suggestion + "\"?"
+	}
+	return reflect.Value{}, fmt.Errorf("%s", message)
+}
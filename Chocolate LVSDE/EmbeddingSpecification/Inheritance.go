@@ -0,0 +1,142 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"strings"
+)
+
+func mergeInheritedEmbeddingSpecifications(normalisedDocument map[string]interface{}) []ValidationProblem {
+	problems := make([]ValidationProblem, 0)
+
+	defaults, _ := normalisedDocument["defaults"].(map[string]interface{})
+	delete(normalisedDocument, "defaults")
+	for _, fieldName := range []string{"name", "extends"} {
+		if _, exists := defaults[fieldName]; exists {
+			problems = append(problems, ValidationProblem{SpecificationIndex: -1, FieldName: "defaults." + fieldName, Message: "cannot be used in defaults", Err: ErrorHandling.ErrInconsistentSpecification})
+		}
+	}
+
+	list, _ := normalisedDocument["embedding_specifications"].([]interface{})
+	entries := make([]map[string]interface{}, len(list))
+	specificationIndicesByName := make(map[string]int)
+	names := make([]string, 0)
+	for i, item := range list {
+		entries[i], _ = item.(map[string]interface{})
+		name, _ := entries[i]["name"].(string)
+		if name == "" {
+			continue
+		}
+		if j, exists := specificationIndicesByName[name]; exists {
+			problems = append(problems, ValidationProblem{SpecificationIndex: i, FieldName: "name", Message: fmt.Sprintf("%q is also the name of embedding specification %d", name, j), Err: ErrorHandling.ErrInconsistentSpecification})
+			continue
+		}
+		specificationIndicesByName[name] = i
+		names = append(names, name)
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	mergedEntries := make([]map[string]interface{}, len(entries))
+	isBeingMerged := make([]bool, len(entries))
+
+	var merge func(i int, extendingChain []string) (map[string]interface{}, *ValidationProblem)
+	merge = func(i int, extendingChain []string) (map[string]interface{}, *ValidationProblem) {
+		if mergedEntries[i] != nil {
+			return mergedEntries[i], nil
+		}
+
+		base := defaults
+		if extends, _ := entries[i]["extends"].(string); extends != "" {
+			j, exists := specificationIndicesByName[extends]
+			if !exists {
+				message := fmt.Sprintf("%q is not the name of an embedding specification", extends)
+				if suggestion := closestFieldName(extends, names); suggestion != "" {
+					message += ", did you mean \"" + suggestion + "\"?"
+				}
+				return nil, &ValidationProblem{SpecificationIndex: i, FieldName: "extends", Message: message, Err: ErrorHandling.ErrInconsistentSpecification}
+			}
+			if j == i {
+				return nil, &ValidationProblem{SpecificationIndex: i, FieldName: "extends", Message: "cannot name the embedding specification itself", Err: ErrorHandling.ErrInconsistentSpecification}
+			}
+			if isBeingMerged[j] {
+				return nil, &ValidationProblem{SpecificationIndex: i, FieldName: "extends", Message: "forms a cycle: " + strings.Join(append(extendingChain, extends), " extends "), Err: ErrorHandling.ErrInconsistentSpecification}
+			}
+
+			isBeingMerged[i] = true
+			extendedEntry, problem := merge(j, append(extendingChain, extends))
+			isBeingMerged[i] = false
+			if problem != nil {
+				return nil, problem
+			}
+
+			base = make(map[string]interface{}, len(extendedEntry))
+			for key, value := range extendedEntry {
+				if key != "name" && key != "extends" {
+					base[key] = value
+				}
+			}
+		}
+
+		mergedEntries[i] = mergeObjects(base, entries[i])
+		return mergedEntries[i], nil
+	}
+
+	isProblemReported := make([]bool, len(entries))
+	for i := range entries {
+		if entries[i] == nil {
+			continue
+		}
+		name, _ := entries[i]["name"].(string)
+		if name == "" {
+			name = fmt.Sprintf("embedding specification %d", i)
+		}
+		mergedEntry, problem := merge(i, []string{name})
+		if problem != nil {
+			if !isProblemReported[problem.SpecificationIndex] {
+				isProblemReported[problem.SpecificationIndex] = true
+				problems = append(problems, *problem)
+			}
+			continue
+		}
+		list[i] = mergedEntry
+	}
+
+	return problems
+}
+
+func mergeObjects(base map[string]interface{}, object map[string]interface{}) map[string]interface{} {
+	mergedObject := make(map[string]interface{}, len(base)+len(object))
+	for key, value := range base {
+		mergedObject[key] = value
+	}
+
+	for key, value := range object {
+		baseObject, isBaseObject := mergedObject[key].(map[string]interface{})
+		valueObject, isValueObject := value.(map[string]interface{})
+		if isBaseObject && isValueObject {
+			mergedObject[key] = mergeObjects(baseObject, valueObject)
+		} else {
+			mergedObject[key] = value
+		}
+	}
+	return mergedObject
+}
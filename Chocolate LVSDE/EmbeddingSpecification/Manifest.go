@@ -0,0 +1,232 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"errors"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/FileReadingOrWriting"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/PythonInterop"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+const ManifestFileName = "manifest.json"
+
+const CurrentManifestVersion = 2
+
+const PythonVersionNotUsed = "not used"
+
+type RunManifest struct {
+	ManifestVersion                       int                            `json:"manifest_version"`
+	Status                                string                         `json:"status"`
+	StartedAt                             string                         `json:"started_at"`
+	FinishedAt                            string                         `json:"finished_at,omitempty"`
+	WallTimeSeconds                       float64                        `json:"wall_time_seconds"`
+	VersionOfUsedChocolateLVSDE           string                         `json:"version_of_used_chocolate_lvsde"`
+	ResolvedEmbeddingSpecification        ResolvedEmbeddingSpecification `json:"resolved_embedding_specification"`
+	FieldOverrides                        []FieldOverride                `json:"field_overrides"`
+	InputFiles                            []InputFileHash                `json:"input_files"`
+	Environment                           RunEnvironment                 `json:"environment"`
+	Seeds                                 DataEmbedding.Seeds            `json:"seeds"`
+	NumberOfParallelGoroutines            int32                          `json:"number_of_parallel_goroutines"`
+	IsDataAbstractionSetPreparationShared bool                           `json:"is_data_abstraction_set_preparation_shared"`
+	StageTimings                          []StageTiming                  `json:"stage_timings"`
+	LVSDEPhaseTimings                     []PhaseTiming                  `json:"lvsde_phase_timings"`
+}
+
+type InputFileHash struct {
+	FieldName   string `json:"field_name"`
+	FilePath    string `json:"file_path"`
+	SHA256      string `json:"sha256"`
+	SizeInBytes int64  `json:"size_in_bytes"`
+}
+
+type RunEnvironment struct {
+	GoVersion          string `json:"go_version"`
+	OperatingSystem    string `json:"operating_system"`
+	Architecture       string `json:"architecture"`
+	NumberOfCPUs       int    `json:"number_of_cpus"`
+	GOMAXPROCS         int    `json:"gomaxprocs"`
+	PythonVersion      string `json:"python_version"`
+	UMAPLearnVersion   string `json:"umap_learn_version"`
+	ScikitLearnVersion string `json:"scikit_learn_version"`
+}
+
+type StageTiming struct {
+	Stage           string  `json:"stage"`
+	WallTimeSeconds float64 `json:"wall_time_seconds"`
+}
+
+type PhaseTiming struct {
+	Phase           int32   `json:"phase"`
+	FirstIteration  int32   `json:"first_iteration"`
+	LastIteration   int32   `json:"last_iteration"`
+	WallTimeSeconds float64 `json:"wall_time_seconds"`
+}
+
+type dataAbstractionSetPreparation struct {
+	InputFiles                         []InputFileHash
+	PythonVersion                      string
+	UMAPLearnVersion                   string
+	ScikitLearnVersion                 string
+	StageTimings                       []StageTiming
+	OriginalDataAbstractionUnitNumbers []int32
+}
+
+func newRunManifest(embeddingSpecification EmbeddingSpecification, resolvedEmbeddingSpecification ResolvedEmbeddingSpecification, preparation dataAbstractionSetPreparation) RunManifest {
+	manifest := RunManifest{
+		ManifestVersion:                CurrentManifestVersion,
+		Status:                         "running",
+		StartedAt:                      time.Now().Format(time.RFC3339),
+		VersionOfUsedChocolateLVSDE:    DataEmbedding.VersionOfChocolateLVSDE,
+		ResolvedEmbeddingSpecification: resolvedEmbeddingSpecification,
+		FieldOverrides:                 embeddingSpecification.FieldOverrides,
+		InputFiles:                     preparation.InputFiles,
+		Environment: RunEnvironment{
+			GoVersion:          runtime.Version(),
+			OperatingSystem:    runtime.GOOS,
+			Architecture:       runtime.GOARCH,
+			NumberOfCPUs:       runtime.NumCPU(),
+			GOMAXPROCS:         runtime.GOMAXPROCS(0),
+			PythonVersion:      preparation.PythonVersion,
+			UMAPLearnVersion:   preparation.UMAPLearnVersion,
+			ScikitLearnVersion: preparation.ScikitLearnVersion,
+		},
+		Seeds:             resolvedEmbeddingSpecification.Seeds,
+		StageTimings:      append([]StageTiming(nil), preparation.StageTimings...),
+		LVSDEPhaseTimings: make([]PhaseTiming, 0),
+	}
+	if manifest.FieldOverrides == nil {
+		manifest.FieldOverrides = make([]FieldOverride, 0)
+	}
+	if manifest.InputFiles == nil {
+		manifest.InputFiles = make([]InputFileHash, 0)
+	}
+	return manifest
+}
+
+func (manifest *RunManifest) finish(err error, startTime time.Time) {
+	manifest.Status = "finished"
+	if errors.Is(err, ErrorHandling.ErrCancelled) {
+		manifest.Status = "cancelled"
+	} else if err != nil {
+		manifest.Status = "failed: " + err.Error()
+	}
+	manifest.FinishedAt = time.Now().Format(time.RFC3339)
+	manifest.WallTimeSeconds = time.Since(startTime).Seconds()
+}
+
+func writeRunManifestFile(outputDirectory string, manifest RunManifest) error {
+	return FileReadingOrWriting.WriteJsonFile(filepath.Join(outputDirectory, ManifestFileName), manifest)
+}
+
+func hashInputFiles(resolvedEmbeddingSpecification ResolvedEmbeddingSpecification) ([]InputFileHash, error) {
+	inputFiles := []InputFileHash{{FieldName: "input_file_path", FilePath: resolvedEmbeddingSpecification.InputFilePath}}
+	if resolvedEmbeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
+		inputFiles = append(inputFiles, InputFileHash{FieldName: "images_file_red_green_blue_channels", FilePath: resolvedEmbeddingSpecification.ImagesFileRedGreenBlueChannels})
+	}
+	if resolvedEmbeddingSpecification.ImagesFileGrayscaleSingleChannel != "" {
+		inputFiles = append(inputFiles, InputFileHash{FieldName: "images_file_grayscale_single_channel", FilePath: resolvedEmbeddingSpecification.ImagesFileGrayscaleSingleChannel})
+	}
+
+	for i := range inputFiles {
+		var err error
+		inputFiles[i].SHA256, inputFiles[i].SizeInBytes, err = FileReadingOrWriting.FileSHA256(inputFiles[i].FilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return inputFiles, nil
+}
+
+func readPythonVersions() (string, string, string) {
+	functionCode := `
+def PythonVersions():
+	import sys
+	versions=[sys.version.split()[0]]
+	for moduleName in ['umap', 'sklearn']:
+		try:
+			module=__import__(moduleName)
+			versions.append(str(getattr(module, '__version__', 'unknown')))
+		except Exception:
+			versions.append('not installed')
+	return tuple(versions)
+`
+	versions := PythonInterop.RunPythonFunctionReturningStrings(functionCode, "PythonVersions")
+	for len(versions) < 3 {
+		versions = append(versions, "unknown")
+	}
+	return versions[0], versions[1], versions[2]
+}
+
+type stageTimer struct {
+	stageTimings   []StageTiming
+	stage          string
+	stageStartTime time.Time
+}
+
+func (stageTimer *stageTimer) startStage(stage string) {
+	stageTimer.finishStage()
+	Logging.SetStage(stage)
+	stageTimer.stage = stage
+	stageTimer.stageStartTime = time.Now()
+}
+
+func (stageTimer *stageTimer) finishStage() {
+	if stageTimer.stage == "" {
+		return
+	}
+	stageTimer.stageTimings = append(stageTimer.stageTimings, StageTiming{Stage: stageTimer.stage, WallTimeSeconds: time.Since(stageTimer.stageStartTime).Seconds()})
+	stageTimer.stage = ""
+}
+
+type phaseTimingProgressObserver struct {
+	phaseTimings   []PhaseTiming
+	phaseStartTime time.Time
+	lastIteration  int32
+}
+
+func (phaseTimingProgressObserver *phaseTimingProgressObserver) IterationCompleted(event DataEmbedding.IterationCompletedEvent) {
+	phaseTimingProgressObserver.lastIteration = event.Iteration
+}
+
+func (phaseTimingProgressObserver *phaseTimingProgressObserver) PhaseChanged(event DataEmbedding.PhaseChangedEvent) {
+	phaseTimingProgressObserver.finishPhase()
+	phaseTimingProgressObserver.phaseTimings = append(phaseTimingProgressObserver.phaseTimings, PhaseTiming{Phase: event.NewPhase, FirstIteration: event.Iteration + 1, LastIteration: -1})
+	phaseTimingProgressObserver.phaseStartTime = time.Now()
+}
+
+func (phaseTimingProgressObserver *phaseTimingProgressObserver) DataAbstractionUnitMovedToGrayLayer(event DataEmbedding.DataAbstractionUnitMovedToGrayLayerEvent) {
+}
+
+func (phaseTimingProgressObserver *phaseTimingProgressObserver) VertexSplit(event DataEmbedding.VertexSplitEvent) {
+}
+
+func (phaseTimingProgressObserver *phaseTimingProgressObserver) finishPhase() {
+	numberOfPhaseTimings := len(phaseTimingProgressObserver.phaseTimings)
+	if numberOfPhaseTimings == 0 || phaseTimingProgressObserver.phaseTimings[numberOfPhaseTimings-1].LastIteration != -1 {
+		return
+	}
+	phaseTiming := &phaseTimingProgressObserver.phaseTimings[numberOfPhaseTimings-1]
+	phaseTiming.LastIteration = phaseTimingProgressObserver.lastIteration
+	phaseTiming.WallTimeSeconds = time.Since(phaseTimingProgressObserver.phaseStartTime).Seconds()
+}
@@ -0,0 +1,46 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+)
+
+type Multilevel struct {
+	CoarsestLevelSize            string `json:"coarsest_level_size"`
+	NumberOfRefinementIterations string `json:"number_of_refinement_iterations"`
+}
+
+func resolveMultilevel(addProblem func(fieldName string, err error, message string), multilevel *Multilevel, phaseSchedule DataEmbedding.PhaseSchedule) *DataEmbedding.MultilevelConfiguration {
+	if multilevel == nil {
+		return nil
+	}
+
+	multilevelConfiguration := DataEmbedding.DefaultMultilevelConfiguration()
+	multilevelConfiguration.CoarsestLevelSize = int32(resolveInteger(addProblem, "multilevel.coarsest_level_size", multilevel.CoarsestLevelSize, 32, 2, int64(DataEmbedding.DefaultCoarsestLevelSize)))
+	multilevelConfiguration.NumberOfRefinementIterations = int32(resolveInteger(addProblem, "multilevel.number_of_refinement_iterations", multilevel.NumberOfRefinementIterations, 32, 1, int64(DataEmbedding.DefaultNumberOfRefinementIterations)))
+
+	lastIterationOfPhaseOne := phaseSchedule.LastIterationOfPhase(1)
+	if multilevel.NumberOfRefinementIterations != "" && multilevelConfiguration.NumberOfRefinementIterations > lastIterationOfPhaseOne {
+		addProblem("multilevel.number_of_refinement_iterations", ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("is %d but phase 1 has only %d iterations", multilevelConfiguration.NumberOfRefinementIterations, lastIterationOfPhaseOne))
+	}
+
+	return &multilevelConfiguration
+}
@@ -0,0 +1,70 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"strings"
+)
+
+type NearestNeighbours struct {
+	Method                    string `json:"method"`
+	MaximumNumberOfIterations string `json:"maximum_number_of_iterations"`
+	TerminationFraction       string `json:"termination_fraction"`
+}
+
+func resolveNearestNeighbours(addProblem func(fieldName string, err error, message string), nearestNeighbours *NearestNeighbours) DataEmbedding.NearestNeighbourConfiguration {
+	nearestNeighbourConfiguration := DataEmbedding.DefaultNearestNeighbourConfiguration()
+	if nearestNeighbours == nil {
+		return nearestNeighbourConfiguration
+	}
+
+	if nearestNeighbours.Method != "" {
+		isKnownMethod := false
+		for _, nearestNeighbourMethod := range DataEmbedding.NearestNeighbourMethods {
+			if nearestNeighbours.Method == nearestNeighbourMethod {
+				isKnownMethod = true
+			}
+		}
+		if isKnownMethod {
+			nearestNeighbourConfiguration.Method = nearestNeighbours.Method
+		} else {
+			addProblem("nearest_neighbours.method", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not one of %s", nearestNeighbours.Method, strings.Join(DataEmbedding.NearestNeighbourMethods, ", ")))
+		}
+	}
+
+	if nearestNeighbours.MaximumNumberOfIterations != "" {
+		if nearestNeighbours.Method != DataEmbedding.NearestNeighbourMethodNNDescent {
+			addProblem("nearest_neighbours.maximum_number_of_iterations", ErrorHandling.ErrInconsistentSpecification, "is only used by the "+DataEmbedding.NearestNeighbourMethodNNDescent+" method")
+		} else {
+			nearestNeighbourConfiguration.MaximumNumberOfIterations = int32(resolveInteger(addProblem, "nearest_neighbours.maximum_number_of_iterations", nearestNeighbours.MaximumNumberOfIterations, 32, 1, int64(DataEmbedding.DefaultMaximumNumberOfNNDescentIterations)))
+		}
+	}
+
+	if nearestNeighbours.TerminationFraction != "" {
+		if nearestNeighbours.Method != DataEmbedding.NearestNeighbourMethodNNDescent {
+			addProblem("nearest_neighbours.termination_fraction", ErrorHandling.ErrInconsistentSpecification, "is only used by the "+DataEmbedding.NearestNeighbourMethodNNDescent+" method")
+		} else {
+			nearestNeighbourConfiguration.TerminationFraction = resolveFraction(addProblem, "nearest_neighbours.termination_fraction", nearestNeighbours.TerminationFraction, false, DataEmbedding.DefaultNNDescentTerminationFraction)
+		}
+	}
+
+	return nearestNeighbourConfiguration
+}
@@ -0,0 +1,249 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/FileReadingOrWriting"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OutputDirectoryPolicyFail           = "fail"
+	OutputDirectoryPolicyOverwrite      = "overwrite"
+	OutputDirectoryPolicyTimestamped    = "timestamped"
+	OutputDirectoryPolicySkipIfComplete = "skip_if_complete"
+)
+
+var OutputDirectoryPolicies = []string{OutputDirectoryPolicyFail, OutputDirectoryPolicyOverwrite, OutputDirectoryPolicyTimestamped, OutputDirectoryPolicySkipIfComplete}
+
+const CompletionMarkerFileName = "run_complete.json"
+
+const LatestSymbolicLinkName = "latest"
+
+type CompletionMarker struct {
+	FinishedAt                  string   `json:"finished_at"`
+	KNNAccuracies               []string `json:"knn_accuracies"`
+	VersionOfUsedChocolateLVSDE string   `json:"version_of_used_chocolate_lvsde"`
+}
+
+func IsValidOutputDirectoryPolicy(outputDirectoryPolicy string) bool {
+	for _, validOutputDirectoryPolicy := range OutputDirectoryPolicies {
+		if outputDirectoryPolicy == validOutputDirectoryPolicy {
+			return true
+		}
+	}
+	return false
+}
+
+func prepareOutputDirectory(outputDirectory string, outputDirectoryPolicy string, protectedFilePaths []string) (string, *CompletionMarker, error) {
+	_, err := os.Stat(outputDirectory)
+	outputDirectoryExists := !os.IsNotExist(err)
+
+	switch outputDirectoryPolicy {
+	case OutputDirectoryPolicyOverwrite:
+		if outputDirectoryExists {
+			err = removeOutputDirectory(outputDirectory, protectedFilePaths)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
+	case OutputDirectoryPolicyTimestamped:
+		err = os.MkdirAll(outputDirectory, FileReadingOrWriting.Chmod)
+		if err != nil {
+			return "", nil, ErrorHandling.NewFileError(outputDirectory, ErrorHandling.ErrFileWrite, err.Error())
+		}
+
+		timestampedDirectoryName := strings.ReplaceAll(time.Now().UTC().Format(time.RFC3339), ":", "-")
+		timestampedOutputDirectory := filepath.Join(outputDirectory, timestampedDirectoryName)
+		for i := 2; ; i++ {
+			if _, err := os.Stat(timestampedOutputDirectory); os.IsNotExist(err) {
+				break
+			}
+			timestampedOutputDirectory = filepath.Join(outputDirectory, timestampedDirectoryName+"_"+strconv.Itoa(i))
+		}
+
+		err = os.Mkdir(timestampedOutputDirectory, FileReadingOrWriting.Chmod)
+		if err != nil {
+			return "", nil, ErrorHandling.NewFileError(timestampedOutputDirectory, ErrorHandling.ErrFileWrite, err.Error())
+		}
+
+		latestSymbolicLinkPath := filepath.Join(outputDirectory, LatestSymbolicLinkName)
+		if fileInfo, err := os.Lstat(latestSymbolicLinkPath); err == nil && fileInfo.Mode()&os.ModeSymlink != 0 {
+			os.Remove(latestSymbolicLinkPath)
+		}
+		err = os.Symlink(filepath.Base(timestampedOutputDirectory), latestSymbolicLinkPath)
+		if err != nil {
+			Logging.Warn("Could not create the latest symbolic link.", Logging.Fields{"file_path": latestSymbolicLinkPath, "error": err.Error()})
+		}
+
+		return timestampedOutputDirectory, nil, nil
+
+	case OutputDirectoryPolicySkipIfComplete:
+		if outputDirectoryExists {
+			completionMarker, err := readCompletionMarker(outputDirectory)
+			if err == nil {
+				return outputDirectory, completionMarker, nil
+			}
+			if !hasRunManifest(outputDirectory) {
+				return "", nil, ErrorHandling.NewFileError(outputDirectory, ErrorHandling.ErrOutputDirectoryExists, "it has neither a completion marker nor a manifest of this run, use the "+OutputDirectoryPolicyOverwrite+" policy to run again")
+			}
+
+			Logging.Warn("Running again because the output directory has a manifest but no completion marker.", Logging.Fields{"output_directory": outputDirectory})
+			err = removeOutputDirectory(outputDirectory, protectedFilePaths)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
+	default:
+		if outputDirectoryExists {
+			return "", nil, ErrorHandling.NewFileError(outputDirectory, ErrorHandling.ErrOutputDirectoryExists, "")
+		}
+	}
+
+	err = os.MkdirAll(outputDirectory, FileReadingOrWriting.Chmod)
+	if err != nil {
+		return "", nil, ErrorHandling.NewFileError(outputDirectory, ErrorHandling.ErrFileWrite, err.Error())
+	}
+
+	return outputDirectory, nil, nil
+}
+
+// existingOutputDirectoryProblem returns why the output directory cannot be used with the policy because it already
+// exists, or an empty string if it can be used.
+func existingOutputDirectoryProblem(outputDirectory string, outputDirectoryPolicy string) string {
+	if _, err := os.Stat(outputDirectory); err != nil {
+		return ""
+	}
+
+	switch outputDirectoryPolicy {
+	case OutputDirectoryPolicyFail:
+		return "already exists"
+	case OutputDirectoryPolicySkipIfComplete:
+		if _, err := readCompletionMarker(outputDirectory); err != nil && !hasRunManifest(outputDirectory) {
+			return "already exists without a completion marker or a manifest of this run"
+		}
+	}
+	return ""
+}
+
+func removeOutputDirectory(outputDirectory string, protectedFilePaths []string) error {
+	absoluteOutputDirectory, err := filepath.Abs(outputDirectory)
+	if err != nil {
+		return ErrorHandling.NewFileError(outputDirectory, ErrorHandling.ErrFileWrite, err.Error())
+	}
+
+	workingDirectory, _ := os.Getwd()
+	homeDirectory, _ := os.UserHomeDir()
+	if filepath.Dir(absoluteOutputDirectory) == absoluteOutputDirectory || absoluteOutputDirectory == workingDirectory || (homeDirectory != "" && absoluteOutputDirectory == filepath.Clean(homeDirectory)) {
+		return ErrorHandling.NewFileError(outputDirectory, ErrorHandling.ErrFileWrite, "refusing to clear a root, working or home directory")
+	}
+
+	for _, protectedFilePath := range protectedFilePaths {
+		absoluteProtectedFilePath, err := filepath.Abs(protectedFilePath)
+		if err != nil {
+			continue
+		}
+		relativePath, err := filepath.Rel(absoluteOutputDirectory, absoluteProtectedFilePath)
+		if err == nil && relativePath != ".." && !strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+			return ErrorHandling.NewFileError(outputDirectory, ErrorHandling.ErrFileWrite, "refusing to clear a directory containing "+protectedFilePath)
+		}
+	}
+
+	Logging.Warn("Clearing the output directory.", Logging.Fields{"output_directory": outputDirectory})
+	err = os.RemoveAll(outputDirectory)
+	if err != nil {
+		return ErrorHandling.NewFileError(outputDirectory, ErrorHandling.ErrFileWrite, err.Error())
+	}
+
+	return nil
+}
+
+func readCompletionMarker(outputDirectory string) (*CompletionMarker, error) {
+	filePath := filepath.Join(outputDirectory, CompletionMarkerFileName)
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, err.Error())
+	}
+
+	completionMarker := new(CompletionMarker)
+	err = json.Unmarshal(bytes, completionMarker)
+	if err != nil {
+		return nil, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, err.Error())
+	}
+
+	return completionMarker, nil
+}
+
+// hasRunManifest reports whether the output directory has a manifest of a run writing to this output directory, that
+// is, whether it was created by an earlier, possibly interrupted, run of the same embedding specification entry.
+func hasRunManifest(outputDirectory string) bool {
+	bytes, err := os.ReadFile(filepath.Join(outputDirectory, ManifestFileName))
+	if err != nil {
+		return false
+	}
+
+	var manifest struct {
+		ResolvedEmbeddingSpecification struct {
+			OutputDirectory string `json:"output_directory"`
+		} `json:"resolved_embedding_specification"`
+	}
+	if json.Unmarshal(bytes, &manifest) != nil || manifest.ResolvedEmbeddingSpecification.OutputDirectory == "" {
+		return false
+	}
+
+	absoluteOutputDirectory, err := filepath.Abs(outputDirectory)
+	if err != nil {
+		return false
+	}
+	absoluteManifestOutputDirectory, err := filepath.Abs(manifest.ResolvedEmbeddingSpecification.OutputDirectory)
+	return err == nil && absoluteManifestOutputDirectory == absoluteOutputDirectory
+}
+
+func writeCompletionMarker(outputDirectory string, knnAccuracies []string) error {
+	var completionMarker CompletionMarker
+	completionMarker.FinishedAt = time.Now().Format(time.RFC3339)
+	completionMarker.KNNAccuracies = knnAccuracies
+	completionMarker.VersionOfUsedChocolateLVSDE = DataEmbedding.VersionOfChocolateLVSDE
+
+	return FileReadingOrWriting.WriteJsonFile(filepath.Join(outputDirectory, CompletionMarkerFileName), completionMarker)
+}
+
+func outputDirectoryPolicyDescription() string {
+	return fmt.Sprintf("%s (default), %s, %s or %s", OutputDirectoryPolicyFail, OutputDirectoryPolicyOverwrite, OutputDirectoryPolicyTimestamped, OutputDirectoryPolicySkipIfComplete)
+}
+
+func OverrideOutputDirectoryPolicy(embeddingSpecifications *EmbeddingSpecifications, outputDirectoryPolicy string) {
+	if outputDirectoryPolicy == "" {
+		return
+	}
+
+	for i := range embeddingSpecifications.EmbeddingSpecifications {
+		embeddingSpecifications.EmbeddingSpecifications[i].OutputDirectoryPolicy = outputDirectoryPolicy
+	}
+}
@@ -0,0 +1,509 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/FileReadingOrWriting"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
+	"html"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const MaximumNumberOfParameterSweepRuns = 1000
+
+const ParameterSweepReportFileName = "parameter_sweep_report"
+
+type ParameterSweep struct {
+	VisualDensityAdjustmentParameters                []string `json:"visual_density_adjustment_parameter"`
+	NumbersOfNeighboursForBuildingNeighbourhoodGraph []string `json:"number_of_neighbours_for_building_neighbourhood_graph"`
+	RandomSeeds                                      []string `json:"random_seed"`
+}
+
+type ParameterSweepRun struct {
+	Name                   string
+	EmbeddingSpecification EmbeddingSpecification
+}
+
+type ParameterSweepRunResult struct {
+	Name                                            string
+	OutputDirectory                                 string
+	VisualDensityAdjustmentParameter                float64
+	NumberOfNeighboursForBuildingNeighbourhoodGraph int32
+	RandomSeed                                      int64
+	Status                                          string
+	WallTime                                        time.Duration
+	KNNAccuracies                                   []string
+	Err                                             error
+}
+
+func ExpandParameterSweep(embeddingSpecification EmbeddingSpecification) ([]ParameterSweepRun, []ValidationProblem) {
+	problems := make([]ValidationProblem, 0)
+	parameterSweep := embeddingSpecification.ParameterSweep
+	if parameterSweep == nil {
+		return nil, problems
+	}
+
+	addProblem := func(fieldName string, err error, message string) {
+		problems = append(problems, ValidationProblem{SpecificationIndex: -1, FieldName: "parameter_sweep." + fieldName, Message: message, Err: err})
+	}
+
+	visualDensityAdjustmentParameters := expandParameterSweepValues(addProblem, "visual_density_adjustment_parameter", parameterSweep.VisualDensityAdjustmentParameters, false)
+	numbersOfNeighbours := expandParameterSweepValues(addProblem, "number_of_neighbours_for_building_neighbourhood_graph", parameterSweep.NumbersOfNeighboursForBuildingNeighbourhoodGraph, true)
+	randomSeeds := expandParameterSweepValues(addProblem, "random_seed", parameterSweep.RandomSeeds, true)
+
+	if len(parameterSweep.VisualDensityAdjustmentParameters) == 0 && len(parameterSweep.NumbersOfNeighboursForBuildingNeighbourhoodGraph) == 0 && len(parameterSweep.RandomSeeds) == 0 {
+		problems = append(problems, ValidationProblem{SpecificationIndex: -1, FieldName: "parameter_sweep", Message: "has no values to sweep", Err: ErrorHandling.ErrInconsistentSpecification})
+	}
+	if embeddingSpecification.OutputDirectory == "" {
+		problems = append(problems, ValidationProblem{SpecificationIndex: -1, FieldName: "output_directory", Message: "is required for a parameter sweep", Err: ErrorHandling.ErrInconsistentSpecification})
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	numberOfRuns := len(visualDensityAdjustmentParameters) * len(numbersOfNeighbours) * len(randomSeeds)
+	if numberOfRuns > MaximumNumberOfParameterSweepRuns {
+		problems = append(problems, ValidationProblem{SpecificationIndex: -1, FieldName: "parameter_sweep", Message: fmt.Sprintf("expands into %d runs but at most %d are allowed", numberOfRuns, MaximumNumberOfParameterSweepRuns), Err: ErrorHandling.ErrInconsistentSpecification})
+		return nil, problems
+	}
+
+	parameterSweepRuns := make([]ParameterSweepRun, 0, numberOfRuns)
+	for _, visualDensityAdjustmentParameter := range visualDensityAdjustmentParameters {
+		for _, numberOfNeighbours := range numbersOfNeighbours {
+			for _, randomSeed := range randomSeeds {
+				var parameterSweepRun ParameterSweepRun
+				parameterSweepRun.EmbeddingSpecification = embeddingSpecification
+				parameterSweepRun.EmbeddingSpecification.ParameterSweep = nil
+
+				nameParts := make([]string, 0, 3)
+				if len(parameterSweep.VisualDensityAdjustmentParameters) > 0 {
+					parameterSweepRun.EmbeddingSpecification.VisualDensityAdjustmentParameter = visualDensityAdjustmentParameter
+					nameParts = append(nameParts, "vdap_"+visualDensityAdjustmentParameter)
+				}
+				if len(parameterSweep.NumbersOfNeighboursForBuildingNeighbourhoodGraph) > 0 {
+					parameterSweepRun.EmbeddingSpecification.NumberOfNeighboursForBuildingNeighbourhoodGraph = numberOfNeighbours
+					nameParts = append(nameParts, "neighbours_"+numberOfNeighbours)
+				}
+				if len(parameterSweep.RandomSeeds) > 0 {
+					parameterSweepRun.EmbeddingSpecification.RandomSeed = randomSeed
+					nameParts = append(nameParts, "seed_"+randomSeed)
+				}
+
+				parameterSweepRun.Name = strings.Join(nameParts, "_")
+				parameterSweepRun.EmbeddingSpecification.OutputDirectory = filepath.Join(embeddingSpecification.OutputDirectory, parameterSweepRun.Name)
+				parameterSweepRuns = append(parameterSweepRuns, parameterSweepRun)
+			}
+		}
+	}
+
+	return parameterSweepRuns, problems
+}
+
+func expandParameterSweepValues(addProblem func(fieldName string, err error, message string), fieldName string, values []string, isInteger bool) []string {
+	if len(values) == 0 {
+		return []string{""}
+	}
+
+	expandedValues := make([]string, 0, len(values))
+	isExpanded := make(map[string]bool)
+	for _, value := range values {
+		rangeValues, err := expandParameterSweepValue(strings.TrimSpace(value), isInteger)
+		if err != nil {
+			addProblem(fieldName, ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q %s", value, err.Error()))
+			continue
+		}
+		for _, rangeValue := range rangeValues {
+			if !isExpanded[rangeValue] {
+				isExpanded[rangeValue] = true
+				expandedValues = append(expandedValues, rangeValue)
+			}
+		}
+		if len(expandedValues) > MaximumNumberOfParameterSweepRuns {
+			addProblem(fieldName, ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("expands into more than %d values", MaximumNumberOfParameterSweepRuns))
+			return expandedValues
+		}
+	}
+
+	return expandedValues
+}
+
+func expandParameterSweepValue(value string, isInteger bool) ([]string, error) {
+	rangeParts := strings.Split(value, ":")
+	if len(rangeParts) > 3 {
+		return nil, errors.New("is neither a value nor a start:stop or start:stop:step range")
+	}
+
+	if isInteger {
+		numbers := make([]int64, len(rangeParts))
+		for i, rangePart := range rangeParts {
+			number, err := strconv.ParseInt(strings.TrimSpace(rangePart), 10, 64)
+			if err != nil {
+				return nil, errors.New("contains a value that is not an integer")
+			}
+			numbers[i] = number
+		}
+		if len(numbers) == 1 {
+			return []string{strconv.FormatInt(numbers[0], 10)}, nil
+		}
+
+		var step int64 = 1
+		if len(numbers) == 3 {
+			step = numbers[2]
+		}
+		if step <= 0 || numbers[1] < numbers[0] {
+			return nil, errors.New("is not a range with start <= stop and a positive step")
+		}
+		if (numbers[1]-numbers[0])/step >= MaximumNumberOfParameterSweepRuns {
+			return nil, fmt.Errorf("expands into more than %d values", MaximumNumberOfParameterSweepRuns)
+		}
+
+		expandedValues := make([]string, 0)
+		for number := numbers[0]; number <= numbers[1]; number += step {
+			expandedValues = append(expandedValues, strconv.FormatInt(number, 10))
+		}
+		return expandedValues, nil
+	}
+
+	numbers := make([]float64, len(rangeParts))
+	for i, rangePart := range rangeParts {
+		number, err := strconv.ParseFloat(strings.TrimSpace(rangePart), 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, errors.New("contains a value that is not a finite number")
+		}
+		numbers[i] = number
+	}
+	if len(numbers) == 1 {
+		return []string{strconv.FormatFloat(numbers[0], 'f', -1, 64)}, nil
+	}
+	if len(numbers) == 2 {
+		return nil, errors.New("is a range without a step, decimal ranges need start:stop:step")
+	}
+
+	step := numbers[2]
+	if step <= 0 || numbers[1] < numbers[0] {
+		return nil, errors.New("is not a range with start <= stop and a positive step")
+	}
+	numberOfSteps := int64(math.Floor((numbers[1]-numbers[0])/step + 1e-9))
+	if numberOfSteps >= MaximumNumberOfParameterSweepRuns {
+		return nil, fmt.Errorf("expands into more than %d values", MaximumNumberOfParameterSweepRuns)
+	}
+
+	expandedValues := make([]string, 0, numberOfSteps+1)
+	for i := int64(0); i <= numberOfSteps; i++ {
+		number := math.Round((numbers[0]+float64(i)*step)*1e9) / 1e9
+		expandedValues = append(expandedValues, strconv.FormatFloat(number, 'f', -1, 64))
+	}
+	return expandedValues, nil
+}
+
+func validateParameterSweep(embeddingSpecification EmbeddingSpecification, outputDirectoryPolicy string) []ValidationProblem {
+	parameterSweepRuns, problems := ExpandParameterSweep(embeddingSpecification)
+	if len(problems) > 0 {
+		return problems
+	}
+
+	isReported := make(map[string]bool)
+	for _, parameterSweepRun := range parameterSweepRuns {
+		_, runProblems := ResolveEmbeddingSpecification(parameterSweepRun.EmbeddingSpecification)
+		for _, runProblem := range runProblems {
+			if !isParameterSweepFieldName(runProblem.FieldName) || isReported[runProblem.Message] {
+				continue
+			}
+			isReported[runProblem.Message] = true
+			runProblem.FieldName = "parameter_sweep." + runProblem.FieldName
+			runProblem.Message = "run " + parameterSweepRun.Name + ": " + runProblem.Message
+			problems = append(problems, runProblem)
+		}
+
+		if message := existingOutputDirectoryProblem(parameterSweepRun.EmbeddingSpecification.OutputDirectory, outputDirectoryPolicy); message != "" {
+			problems = append(problems, ValidationProblem{SpecificationIndex: -1, FieldName: "output_directory", FilePath: parameterSweepRun.EmbeddingSpecification.OutputDirectory, Message: message, Err: ErrorHandling.ErrOutputDirectoryExists})
+		}
+	}
+
+	return problems
+}
+
+func isParameterSweepFieldName(fieldName string) bool {
+	return fieldName == "visual_density_adjustment_parameter" || fieldName == "number_of_neighbours_for_building_neighbourhood_graph" || fieldName == "random_seed"
+}
+
+func runParameterSweep(ctx context.Context, embeddingSpecification EmbeddingSpecification, resolvedEmbeddingSpecification ResolvedEmbeddingSpecification) (EmbeddingSpecificationRunResult, error) {
+	var runResult EmbeddingSpecificationRunResult
+	runResult.OutputDirectory = resolvedEmbeddingSpecification.OutputDirectory
+
+	parameterSweepRuns, problems := ExpandParameterSweep(embeddingSpecification)
+	if len(problems) > 0 {
+		return runResult, problems[0]
+	}
+
+	resolvedParameterSweepRuns := make([]ResolvedEmbeddingSpecification, len(parameterSweepRuns))
+	for i, parameterSweepRun := range parameterSweepRuns {
+		resolvedParameterSweepRun, problems := ResolveEmbeddingSpecification(parameterSweepRun.EmbeddingSpecification)
+		if len(problems) > 0 {
+			return runResult, fmt.Errorf("parameter sweep run %s: %w", parameterSweepRun.Name, problems[0])
+		}
+		resolvedParameterSweepRuns[i] = resolvedParameterSweepRun
+	}
+
+	err := os.MkdirAll(resolvedEmbeddingSpecification.OutputDirectory, FileReadingOrWriting.Chmod)
+	if err != nil {
+		return runResult, ErrorHandling.NewFileError(resolvedEmbeddingSpecification.OutputDirectory, ErrorHandling.ErrFileWrite, err.Error())
+	}
+
+	Logging.Info("Running parameter sweep.", Logging.Fields{"number_of_parameter_sweep_runs": len(parameterSweepRuns)})
+
+	var preparedDataAbstractionSet *DataAbstraction.DataAbstractionSet
+	var preparation dataAbstractionSetPreparation
+	var preparationErr error
+	prepareSharedDataAbstractionSet := func() (*DataAbstraction.DataAbstractionSet, dataAbstractionSetPreparation, error) {
+		if preparedDataAbstractionSet != nil || preparationErr != nil {
+			return preparedDataAbstractionSet, preparation, preparationErr
+		}
+
+		runtime.GC()
+		dataAbstractionSet, dataAbstractionSetPreparation, err := prepareDataAbstractionSet(ctx, resolvedParameterSweepRuns[0])
+		if err == nil && resolvedParameterSweepRuns[0].DistanceStorage != DataEmbedding.DistanceStorageSparse {
+			stageTimer := stageTimer{stageTimings: dataAbstractionSetPreparation.StageTimings}
+			stageTimer.startStage("distances_after_transformation")
+			nearestNeighbourSearch := DataEmbedding.NewNearestNeighbourSearch(resolvedParameterSweepRuns[0].NearestNeighbours, resolvedParameterSweepRuns[0].Seeds.LVSDEInitialisation, DataAbstraction.DefaultNumberOfParallelSlices())
+			err = dataAbstractionSet.ComputeDistancesAfterTransformation(ctx, nearestNeighbourSearch)
+			stageTimer.finishStage()
+			dataAbstractionSetPreparation.StageTimings = stageTimer.stageTimings
+		}
+		if err != nil {
+			preparationErr = err
+			return nil, preparation, preparationErr
+		}
+
+		preparedDataAbstractionSet = &dataAbstractionSet
+		preparation = dataAbstractionSetPreparation
+		return preparedDataAbstractionSet, preparation, nil
+	}
+
+	parameterSweepRunResults := make([]ParameterSweepRunResult, 0, len(parameterSweepRuns))
+	var firstErr error
+	for i, parameterSweepRun := range parameterSweepRuns {
+		if ctx.Err() != nil {
+			firstErr = ErrorHandling.NewCancellationError("waiting to start parameter sweep run "+parameterSweepRun.Name, 0, ctx.Err())
+			break
+		}
+
+		Logging.Info("Running parameter sweep run.", Logging.Fields{"parameter_sweep_run": parameterSweepRun.Name, "parameter_sweep_run_number": i + 1, "number_of_parameter_sweep_runs": len(parameterSweepRuns)})
+		parameterSweepRunResult := runParameterSweepRun(ctx, parameterSweepRun, resolvedParameterSweepRuns[i], prepareSharedDataAbstractionSet)
+		parameterSweepRunResults = append(parameterSweepRunResults, parameterSweepRunResult)
+
+		if parameterSweepRunResult.Err != nil {
+			Logging.Error("Parameter sweep run not finished.", Logging.Fields{"parameter_sweep_run": parameterSweepRun.Name, "status": parameterSweepRunResult.Status})
+			if firstErr == nil {
+				firstErr = fmt.Errorf("parameter sweep run %s: %w", parameterSweepRun.Name, parameterSweepRunResult.Err)
+			}
+			if errors.Is(parameterSweepRunResult.Err, ErrorHandling.ErrCancelled) || preparationErr != nil {
+				break
+			}
+		}
+	}
+
+	RankParameterSweepRunResults(parameterSweepRunResults)
+	runResult.ParameterSweepRunResults = parameterSweepRunResults
+	if len(parameterSweepRunResults) > 0 {
+		runResult.KNNAccuracies = parameterSweepRunResults[0].KNNAccuracies
+	}
+
+	Logging.SetStage("saving")
+	err = WriteParameterSweepReportFiles(filepath.Join(resolvedEmbeddingSpecification.OutputDirectory, ParameterSweepReportFileName), parameterSweepRunResults, resolvedEmbeddingSpecification.EvaluationNeighbourhoodSizes)
+	if err != nil && firstErr == nil {
+		firstErr = err
+	}
+	Logging.Info("Parameter sweep report written.", Logging.Fields{"file_path": filepath.Join(resolvedEmbeddingSpecification.OutputDirectory, ParameterSweepReportFileName+".csv")})
+
+	return runResult, firstErr
+}
+
+func runParameterSweepRun(ctx context.Context, parameterSweepRun ParameterSweepRun, resolvedParameterSweepRun ResolvedEmbeddingSpecification, prepareSharedDataAbstractionSet func() (*DataAbstraction.DataAbstractionSet, dataAbstractionSetPreparation, error)) (parameterSweepRunResult ParameterSweepRunResult) {
+	parameterSweepRunResult.Name = parameterSweepRun.Name
+	parameterSweepRunResult.OutputDirectory = resolvedParameterSweepRun.OutputDirectory
+	parameterSweepRunResult.VisualDensityAdjustmentParameter = resolvedParameterSweepRun.VisualDensityAdjustmentParameter
+	parameterSweepRunResult.NumberOfNeighboursForBuildingNeighbourhoodGraph = resolvedParameterSweepRun.EffectiveNumberOfNeighboursForBuildingNeighbourhoodGraph()
+	parameterSweepRunResult.RandomSeed = resolvedParameterSweepRun.RandomSeed
+	startTime := time.Now()
+
+	defer func() {
+		parameterSweepRunResult.WallTime = time.Since(startTime)
+		if recovered := recover(); recovered != nil {
+			parameterSweepRunResult.Err = fmt.Errorf("%v", recovered)
+		}
+		if errors.Is(parameterSweepRunResult.Err, ErrorHandling.ErrCancelled) {
+			parameterSweepRunResult.Status = parameterSweepRunResult.Err.Error()
+		} else if parameterSweepRunResult.Err != nil {
+			parameterSweepRunResult.Status = "failed: " + parameterSweepRunResult.Err.Error()
+		}
+	}()
+
+	outputDirectory, completionMarker, err := prepareOutputDirectory(resolvedParameterSweepRun.OutputDirectory, resolvedParameterSweepRun.OutputDirectoryPolicy, resolvedParameterSweepRun.ProtectedFilePaths())
+	if err != nil {
+		parameterSweepRunResult.Err = err
+		return parameterSweepRunResult
+	}
+	parameterSweepRunResult.OutputDirectory = outputDirectory
+
+	if completionMarker != nil {
+		Logging.Info("Skipping the parameter sweep run because its output directory has a completion marker.", Logging.Fields{"output_directory": outputDirectory, "finished_at": completionMarker.FinishedAt})
+		parameterSweepRunResult.KNNAccuracies = completionMarker.KNNAccuracies
+		parameterSweepRunResult.Status = "skipped: already complete"
+		return parameterSweepRunResult
+	}
+
+	dataAbstractionSet, preparation, err := prepareSharedDataAbstractionSet()
+	if err != nil {
+		parameterSweepRunResult.Err = err
+		return parameterSweepRunResult
+	}
+
+	embeddingSpecification := parameterSweepRun.EmbeddingSpecification
+	embeddingSpecification.OutputDirectory = outputDirectory
+	resolvedParameterSweepRun.OutputDirectory = outputDirectory
+
+	parameterSweepRunResult.KNNAccuracies, err = embedPreparedDataAbstractionSet(ctx, embeddingSpecification, resolvedParameterSweepRun, dataAbstractionSet.Copy(), preparation, true)
+	if err == nil {
+		err = writeCompletionMarker(outputDirectory, parameterSweepRunResult.KNNAccuracies)
+	}
+	parameterSweepRunResult.Err = err
+	if err == nil {
+		parameterSweepRunResult.Status = "finished"
+	}
+	return parameterSweepRunResult
+}
+
+func knnAccuracyPercentages(knnAccuracies []string) []float64 {
+	percentages := make([]float64, 0, len(knnAccuracies))
+	for _, knnAccuracy := range knnAccuracies {
+		percentage := knnAccuracy[strings.LastIndex(knnAccuracy, " ")+1:]
+		value, err := strconv.ParseFloat(strings.TrimSuffix(percentage, "%"), 64)
+		if err != nil {
+			value = math.Inf(-1)
+		}
+		percentages = append(percentages, value)
+	}
+	return percentages
+}
+
+func RankParameterSweepRunResults(parameterSweepRunResults []ParameterSweepRunResult) {
+	sort.SliceStable(parameterSweepRunResults, func(i, j int) bool {
+		percentagesI := knnAccuracyPercentages(parameterSweepRunResults[i].KNNAccuracies)
+		percentagesJ := knnAccuracyPercentages(parameterSweepRunResults[j].KNNAccuracies)
+		for k := 0; k < len(percentagesI) && k < len(percentagesJ); k++ {
+			if percentagesI[k] != percentagesJ[k] {
+				return percentagesI[k] > percentagesJ[k]
+			}
+		}
+		return len(percentagesI) > len(percentagesJ)
+	})
+}
+
+func WriteParameterSweepReportFiles(filePathWithoutExtension string, parameterSweepRunResults []ParameterSweepRunResult, evaluationNeighbourhoodSizes []int) error {
+	knnAccuracyColumnNames := make([]string, len(evaluationNeighbourhoodSizes))
+	for i, k := range evaluationNeighbourhoodSizes {
+		knnAccuracyColumnNames[i] = "KNN_accuracy_k=" + strconv.Itoa(k) + "_(red_and_gray)_(red_and_gray)"
+	}
+
+	report := strings.Builder{}
+	report.WriteString("Rank,Name,Output_directory,Visual_density_adjustment_parameter,Number_of_neighbours_for_building_neighbourhood_graph,Random_seed,Status,Wall_time_seconds")
+	for _, knnAccuracyColumnName := range knnAccuracyColumnNames {
+		report.WriteString("," + knnAccuracyColumnName)
+	}
+	report.WriteString("\r\n")
+
+	table := strings.Builder{}
+	table.WriteString("<tr><th>Rank</th><th>Name</th><th>Visual density adjustment parameter</th><th>Number of neighbours</th><th>Random seed</th><th>Status</th><th>Wall time</th>")
+	for _, knnAccuracyColumnName := range knnAccuracyColumnNames {
+		table.WriteString("<th>" + html.EscapeString(knnAccuracyColumnName) + "</th>")
+	}
+	table.WriteString("</tr>\r\n")
+
+	for rank, parameterSweepRunResult := range parameterSweepRunResults {
+		percentages := make([]string, len(evaluationNeighbourhoodSizes))
+		for i, percentage := range knnAccuracyPercentages(parameterSweepRunResult.KNNAccuracies) {
+			if i < len(percentages) && !math.IsInf(percentage, -1) {
+				percentages[i] = strconv.FormatFloat(percentage, 'f', 3, 64)
+			}
+		}
+
+		visualDensityAdjustmentParameter := strconv.FormatFloat(parameterSweepRunResult.VisualDensityAdjustmentParameter, 'f', -1, 64)
+		numberOfNeighbours := strconv.Itoa(int(parameterSweepRunResult.NumberOfNeighboursForBuildingNeighbourhoodGraph))
+		randomSeed := strconv.FormatInt(parameterSweepRunResult.RandomSeed, 10)
+		wallTimeSeconds := strconv.FormatFloat(parameterSweepRunResult.WallTime.Seconds(), 'f', 3, 64)
+
+		report.WriteString(strconv.Itoa(rank+1) + "," +
+			strconv.Quote(parameterSweepRunResult.Name) + "," +
+			strconv.Quote(parameterSweepRunResult.OutputDirectory) + "," +
+			visualDensityAdjustmentParameter + "," + numberOfNeighbours + "," + randomSeed + "," +
+			strconv.Quote(parameterSweepRunResult.Status) + "," + wallTimeSeconds)
+		for _, percentage := range percentages {
+			report.WriteString("," + percentage)
+		}
+		report.WriteString("\r\n")
+
+		runLink := html.EscapeString(parameterSweepRunResult.Name)
+		if parameterSweepRunResult.Err == nil {
+			relativeOutputDirectory, err := filepath.Rel(filepath.Dir(filePathWithoutExtension), parameterSweepRunResult.OutputDirectory)
+			if err == nil {
+				runLink = "<a href=\"" + html.EscapeString(filepath.ToSlash(filepath.Join(relativeOutputDirectory, "show.html"))) + "\">" + runLink + "</a>"
+			}
+		}
+		table.WriteString("<tr><td>" + strconv.Itoa(rank+1) + "</td><td>" + runLink + "</td><td>" + visualDensityAdjustmentParameter + "</td><td>" + numberOfNeighbours + "</td><td>" + randomSeed + "</td><td>" + html.EscapeString(parameterSweepRunResult.Status) + "</td><td>" + wallTimeSeconds + "s</td>")
+		for _, percentage := range percentages {
+			if percentage != "" {
+				percentage += "%"
+			}
+			table.WriteString("<td>" + percentage + "</td>")
+		}
+		table.WriteString("</tr>\r\n")
+	}
+
+	err := FileReadingOrWriting.WriteFile(filePathWithoutExtension+".csv", []byte(report.String()))
+	if err != nil {
+		return err
+	}
+
+	var htmlReport strings.Builder
+	htmlReport.WriteString("<html>\r\n")
+	htmlReport.WriteString("<head><title>Parameter sweep report</title></head>\r\n")
+	htmlReport.WriteString("<style>table{border-collapse:collapse;}td,th{border:1px solid black;padding:4px 8px;text-align:left;}</style>\r\n")
+	htmlReport.WriteString("<body style=\"font-size:18px;\">\r\n")
+	htmlReport.WriteString("<div style=\"margin:10px;\">Runs ranked by KNN accuracy (red and gray layers), best first.</div>\r\n")
+	htmlReport.WriteString("<table style=\"margin:10px;\">\r\n")
+	htmlReport.WriteString(table.String())
+	htmlReport.WriteString("</table>\r\n")
+	htmlReport.WriteString("</body>\r\n")
+	htmlReport.WriteString("</html>\r\n")
+
+	return FileReadingOrWriting.WriteFile(filePathWithoutExtension+".html", []byte(htmlReport.String()))
+}
@@ -0,0 +1,86 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"math"
+	"strconv"
+)
+
+const MaximumNumberOfIterationsOfPhase = 1000000
+
+type PhaseSchedule struct {
+	IterationsPerPhase []string `json:"iterations_per_phase"`
+	ScaleFactor        string   `json:"scale_factor"`
+}
+
+func resolvePhaseSchedule(addProblem func(fieldName string, err error, message string), phaseSchedule *PhaseSchedule) DataEmbedding.PhaseSchedule {
+	if phaseSchedule == nil {
+		return DataEmbedding.DefaultPhaseSchedule()
+	}
+
+	if phaseSchedule.IterationsPerPhase != nil && phaseSchedule.ScaleFactor != "" {
+		addProblem("phase_schedule.scale_factor", ErrorHandling.ErrInconsistentSpecification, "cannot be used together with phase_schedule.iterations_per_phase")
+		return DataEmbedding.DefaultPhaseSchedule()
+	}
+
+	if phaseSchedule.ScaleFactor != "" {
+		scaleFactor, err := strconv.ParseFloat(phaseSchedule.ScaleFactor, 64)
+		if err != nil || math.IsNaN(scaleFactor) || math.IsInf(scaleFactor, 0) || scaleFactor <= 0 {
+			addProblem("phase_schedule.scale_factor", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not a positive finite number", phaseSchedule.ScaleFactor))
+			return DataEmbedding.DefaultPhaseSchedule()
+		}
+		if float64(DataEmbedding.DefaultPhaseScheduleNumberOfIterations())*scaleFactor > DataEmbedding.NumberOfPhases*MaximumNumberOfIterationsOfPhase {
+			addProblem("phase_schedule.scale_factor", ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("is %g which gives more than %d iterations in a phase", scaleFactor, MaximumNumberOfIterationsOfPhase))
+			return DataEmbedding.DefaultPhaseSchedule()
+		}
+		return DataEmbedding.ScaledPhaseSchedule(scaleFactor)
+	}
+
+	if phaseSchedule.IterationsPerPhase != nil {
+		if len(phaseSchedule.IterationsPerPhase) != DataEmbedding.NumberOfPhases {
+			addProblem("phase_schedule.iterations_per_phase", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("has %d values but there are %d phases", len(phaseSchedule.IterationsPerPhase), DataEmbedding.NumberOfPhases))
+			return DataEmbedding.DefaultPhaseSchedule()
+		}
+		var numbersOfIterationsOfPhases [DataEmbedding.NumberOfPhases]int32
+		isValid := true
+		for i, iterations := range phaseSchedule.IterationsPerPhase {
+			fieldName := "phase_schedule.iterations_per_phase[" + strconv.Itoa(i) + "]"
+			if iterations == "" {
+				addProblem(fieldName, ErrorHandling.ErrUnparsableSpecification, "is empty")
+			}
+			numberOfIterations := resolveInteger(addProblem, fieldName, iterations, 32, 1, -1)
+			if numberOfIterations > MaximumNumberOfIterationsOfPhase {
+				addProblem(fieldName, ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("is %d but must be at most %d", numberOfIterations, MaximumNumberOfIterationsOfPhase))
+				numberOfIterations = -1
+			}
+			if numberOfIterations == -1 {
+				isValid = false
+			}
+			numbersOfIterationsOfPhases[i] = int32(numberOfIterations)
+		}
+		if isValid {
+			return DataEmbedding.NewPhaseSchedule(numbersOfIterationsOfPhases)
+		}
+	}
+
+	return DataEmbedding.DefaultPhaseSchedule()
+}
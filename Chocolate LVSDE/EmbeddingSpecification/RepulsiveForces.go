@@ -0,0 +1,61 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"strings"
+)
+
+type RepulsiveForces struct {
+	Method       string `json:"method"`
+	OpeningAngle string `json:"opening_angle"`
+}
+
+func resolveRepulsiveForces(addProblem func(fieldName string, err error, message string), repulsiveForces *RepulsiveForces) DataEmbedding.RepulsiveForceConfiguration {
+	repulsiveForceConfiguration := DataEmbedding.DefaultRepulsiveForceConfiguration()
+	if repulsiveForces == nil {
+		return repulsiveForceConfiguration
+	}
+
+	if repulsiveForces.Method != "" {
+		isKnownMethod := false
+		for _, repulsiveForceMethod := range DataEmbedding.RepulsiveForceMethods {
+			if repulsiveForces.Method == repulsiveForceMethod {
+				isKnownMethod = true
+			}
+		}
+		if isKnownMethod {
+			repulsiveForceConfiguration.Method = repulsiveForces.Method
+		} else {
+			addProblem("repulsive_forces.method", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not one of %s", repulsiveForces.Method, strings.Join(DataEmbedding.RepulsiveForceMethods, ", ")))
+		}
+	}
+
+	if repulsiveForces.OpeningAngle != "" {
+		if repulsiveForces.Method != DataEmbedding.RepulsiveForceMethodBarnesHut {
+			addProblem("repulsive_forces.opening_angle", ErrorHandling.ErrInconsistentSpecification, "is only used by the "+DataEmbedding.RepulsiveForceMethodBarnesHut+" method")
+		} else {
+			repulsiveForceConfiguration.OpeningAngle = resolveFraction(addProblem, "repulsive_forces.opening_angle", repulsiveForces.OpeningAngle, true, DataEmbedding.DefaultOpeningAngle)
+		}
+	}
+
+	return repulsiveForceConfiguration
+}
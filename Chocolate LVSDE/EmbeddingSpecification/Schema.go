@@ -0,0 +1,456 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	FieldTypeString                 = "string"
+	FieldTypePath                   = "path"
+	FieldTypeBoolean                = "boolean"
+	FieldTypeInteger                = "integer"
+	FieldTypeNumber                 = "number"
+	FieldTypeListOfStrings          = "list of strings"
+	FieldTypeListOfColours          = "list of colours"
+	FieldTypeListOfIntegers         = "list of integers"
+	FieldTypeListOfValuesOrRanges   = "list of values or ranges"
+	FieldTypeListOfIntegersOrRanges = "list of integers or ranges"
+	FieldTypeObject                 = "object"
+)
+
+type FieldDescription struct {
+	FieldName                 string
+	Type                      string
+	IsRequired                bool
+	Default                   interface{}
+	DefaultDescription        string
+	Minimum                   *int64
+	AllowedValues             []string
+	ExcludedFieldNames        []string
+	RequiresOneOfFieldNames   []string
+	Description               string
+	FieldDescriptionsOfObject []FieldDescription
+}
+
+func minimumOf(minimum int64) *int64 {
+	return &minimum
+}
+
+var embeddingSpecificationFieldDescriptions = map[string]FieldDescription{
+	"spec_version": {Type: FieldTypeInteger, Default: CurrentSpecificationVersion, Minimum: minimumOf(1),
+		Description: "Version of the structure of the embedding specifications file. Older versions are migrated when the file is read."},
+	"defaults": {Type: FieldTypeObject,
+		Description: "Fields which every embedding specification inherits unless it specifies them itself. Can contain any field of an embedding specification except name and extends."},
+	"embedding_specifications": {Type: FieldTypeObject, IsRequired: true,
+		Description: "List of embedding specifications which are run one after another."},
+	"name": {Type: FieldTypeString,
+		Description: "Name of the embedding specification which other embedding specifications can extend. Must be unique."},
+	"extends": {Type: FieldTypeString,
+		Description: "Name of another embedding specification whose fields, including the inherited ones, this embedding specification inherits instead of defaults. Fields specified here override the inherited ones and objects are merged field by field."},
+	"input_file_path": {Type: FieldTypePath, IsRequired: true,
+		Description: "CSV file with one row per data abstraction unit, either multi-dimensional coordinates or a distance matrix."},
+	"is_input_file_distances": {Type: FieldTypeBoolean, IsRequired: true,
+		Description: "Whether the input file contains a distance matrix instead of multi-dimensional coordinates."},
+	"output_directory": {Type: FieldTypePath, IsRequired: true,
+		Description: "Directory where the embedding, the images and the evaluation results are written."},
+	"class_labels": {Type: FieldTypeListOfStrings, Default: DefaultClassLabels,
+		Description: "Names of the classes in the order of the class label numbers of the input file."},
+	"colours_list": {Type: FieldTypeListOfColours, Default: DefaultColoursList, DefaultDescription: "the first colours of a list of ten default colours, one for each class label",
+		Description: "Colour of each class label in the form #RRGGBB."},
+	"images_file_red_green_blue_channels": {Type: FieldTypePath, ExcludedFieldNames: []string{"images_file_grayscale_single_channel"},
+		Description: "CSV file with the red, green and blue pixel values of an image for each data abstraction unit."},
+	"images_file_grayscale_single_channel": {Type: FieldTypePath, ExcludedFieldNames: []string{"images_file_red_green_blue_channels"},
+		Description: "CSV file with the grayscale pixel values of an image for each data abstraction unit."},
+	"images_file_image_width": {Type: FieldTypeInteger, Minimum: minimumOf(1), DefaultDescription: "the square root of the number of pixels of an image",
+		RequiresOneOfFieldNames: []string{"images_file_red_green_blue_channels", "images_file_grayscale_single_channel"},
+		Description:             "Width in pixels of the images of the images file."},
+	"images_file_has_class_label_numbers": {Type: FieldTypeBoolean, Default: false,
+		RequiresOneOfFieldNames: []string{"images_file_red_green_blue_channels", "images_file_grayscale_single_channel"},
+		Description:             "Whether the first column of the images file is the class label number."},
+	"random_seed": {Type: FieldTypeInteger, Default: DataEmbedding.DefaultRandomSeed, DefaultDescription: "derived from master_seed when master_seed is specified, otherwise derived from random_state when random_state is specified, otherwise " + strconv.FormatInt(DataEmbedding.DefaultRandomSeed, 10),
+		ExcludedFieldNames: []string{"random_state"},
+		Description:        "Seed of the random number generator of LVSDE initialisation."},
+	"random_state": {Type: FieldTypeInteger, Default: 5, Minimum: minimumOf(0), ExcludedFieldNames: []string{"random_seed"}, DefaultDescription: "derived from master_seed when master_seed is specified, otherwise 5",
+		Description: "Random state of UMAP and t-SNE, also used to derive the seed of LVSDE when neither random_seed nor master_seed is specified."},
+	"master_seed": {Type: FieldTypeInteger, DefaultDescription: "not set, in which case random_seed and random_state are used and rendering and evaluation use a fixed shuffling seed",
+		Description: "Seed from which the seeds of LVSDE initialisation, UMAP, t-SNE, render shuffling, evaluation tie breaking and subsampling of secondary data abstraction units are derived. An explicit random_seed or random_state takes precedence for the seeds it covers."},
+	"preliminary_to_thirty_dimensions_umap": {Type: FieldTypeBoolean, Default: true,
+		Description: "Whether multi-dimensional input is first reduced to thirty dimensions with UMAP."},
+	"number_of_initial_data_abstraction_units": {Type: FieldTypeInteger, IsRequired: true, Minimum: minimumOf(MinimumNumberOfDataAbstractionUnits),
+		Description: "Number of data abstraction units read from the input file."},
+	"number_of_secondary_data_abstraction_units": {Type: FieldTypeInteger, Minimum: minimumOf(MinimumNumberOfDataAbstractionUnits), DefaultDescription: "all initial data abstraction units",
+		Description: "Number of the initial data abstraction units which are embedded."},
+	"visual_density_adjustment_parameter": {Type: FieldTypeNumber, Default: DataEmbedding.DefaultVisualDensityAdjustmentParameter,
+		Description: "Visual density adjustment parameter of LVSDE."},
+	"number_of_neighbours_for_building_neighbourhood_graph": {Type: FieldTypeInteger, Minimum: minimumOf(1), DefaultDescription: "number_of_initial_data_abstraction_units / 3",
+		Description: "Number of neighbours of each data abstraction unit in the neighbourhood graph. Must be less than the number of embedded data abstraction units, and must be set when distance_storage is sparse."},
+	"evaluation_neighbourhood_sizes": {Type: FieldTypeListOfIntegers, Minimum: minimumOf(1), Default: []int{},
+		Description: "Neighbourhood sizes of the KNN accuracy evaluation. Each must be less than the number of embedded data abstraction units."},
+	"compare_with_other_methods": {Type: FieldTypeBoolean, Default: false,
+		Description: "Whether UMAP and t-SNE embeddings are also made and evaluated for comparison."},
+	"use_cosine_distance_for_input_multi_dimensional_data": {Type: FieldTypeBoolean, Default: false,
+		Description: "Whether cosine distance instead of Euclidean distance is used for multi-dimensional input."},
+	"output_directory_policy": {Type: FieldTypeString, Default: OutputDirectoryPolicyFail, AllowedValues: OutputDirectoryPolicies,
+		Description: "What happens when the output directory already exists. skip_if_complete skips an entry whose output directory has a completion marker and runs an entry interrupted before finishing again."},
+	"phase_schedule": {Type: FieldTypeObject, DefaultDescription: "phases of 500, 450, 390 and 490 iterations",
+		Description: "Number of iterations of each of the four phases of LVSDE. Fewer iterations trade layout quality for speed."},
+	"phase_schedule.iterations_per_phase": {Type: FieldTypeListOfIntegers,
+		ExcludedFieldNames: []string{"scale_factor"},
+		Description:        "Four positive numbers of iterations, one for each phase. The cooling of the temperature is stretched by the ratio of their sum to 1830."},
+	"phase_schedule.scale_factor": {Type: FieldTypeNumber,
+		ExcludedFieldNames: []string{"iterations_per_phase"},
+		Description:        "Positive factor by which the default numbers of iterations of all phases and the cooling of the temperature are stretched."},
+	"cooling_schedule": {Type: FieldTypeObject, DefaultDescription: "linear cooling",
+		Description: "How the temperature, which limits the displacement of a visual space projection in an iteration, is lowered during each phase."},
+	"cooling_schedule.type": {Type: FieldTypeString, Default: DataEmbedding.CoolingScheduleTypeLinear, AllowedValues: DataEmbedding.CoolingScheduleTypes,
+		Description: "linear lowers the temperature by the same amount every iteration, exponential and cosine lower it between the same start and end temperatures of each phase along an exponential or half cosine curve, and adaptive lowers it linearly but shrinks it whenever the mean displacement stops decreasing."},
+	"cooling_schedule.minimum_temperature_ratio": {Type: FieldTypeNumber, Default: DataEmbedding.DefaultMinimumTemperatureRatio,
+		Description: "Lowest temperature of the exponential cooling schedule as a fraction of the initial temperature, greater than 0 and at most 1."},
+	"cooling_schedule.shrink_factor": {Type: FieldTypeNumber, Default: DataEmbedding.DefaultShrinkFactor,
+		Description: "Factor, greater than 0 and less than 1, by which the adaptive cooling schedule shrinks the temperature."},
+	"cooling_schedule.patience": {Type: FieldTypeInteger, Default: DataEmbedding.DefaultPatience, Minimum: minimumOf(1),
+		Description: "Number of iterations without a decrease of the lowest mean displacement of the phase after which the adaptive cooling schedule shrinks the temperature."},
+	"convergence_criterion": {Type: FieldTypeObject, DefaultDescription: "every phase runs all of its iterations",
+		Description: "Ends phase 1, 3 or 4 early once the mean displacement of the visual space projections, relative to the diagonal of their bounding box, has stayed below a threshold for a number of consecutive iterations. The iteration at which each phase ended is recorded."},
+	"convergence_criterion.relative_mean_displacement_threshold": {Type: FieldTypeNumber, Default: DataEmbedding.DefaultRelativeMeanDisplacementThreshold,
+		Description: "Threshold, greater than 0 and at most 1, below which the relative mean displacement of an iteration counts as converged."},
+	"convergence_criterion.number_of_converged_iterations": {Type: FieldTypeInteger, Default: DataEmbedding.DefaultNumberOfConvergedIterations, Minimum: minimumOf(1),
+		Description: "Number of consecutive converged iterations after which the phase ends."},
+	"repulsive_forces": {Type: FieldTypeObject, DefaultDescription: "exact repulsive forces",
+		Description: "How the repulsive forces between visual space projections, and the replication pressures that they cause, are calculated in each iteration."},
+	"repulsive_forces.method": {Type: FieldTypeString, Default: DataEmbedding.RepulsiveForceMethodExact, AllowedValues: DataEmbedding.RepulsiveForceMethods,
+		Description: "exact compares every visual space projection with every other one, which takes time quadratic in their number, and barnes_hut approximates the forces of distant groups of projections using a quadtree rebuilt in every iteration."},
+	"repulsive_forces.opening_angle": {Type: FieldTypeNumber, Default: DataEmbedding.DefaultOpeningAngle,
+		Description: "Opening angle, greater than 0 and at most 1, of the barnes_hut method. A quadtree cell whose size divided by its distance is below it is treated as a single projection, so lower values are more accurate and slower."},
+	"multilevel": {Type: FieldTypeObject, DefaultDescription: "a single level",
+		Description: "Repeatedly coarsens the neighbourhood graph by merging close data abstraction units into heavier super-units, runs phase 1 on the coarsest level and then refines the layout level by level. Layer assignment and vertex splitting only run on the finest level."},
+	"multilevel.coarsest_level_size": {Type: FieldTypeInteger, Default: DataEmbedding.DefaultCoarsestLevelSize, Minimum: minimumOf(2),
+		Description: "Coarsening stops once a level has at most this many super-units."},
+	"multilevel.number_of_refinement_iterations": {Type: FieldTypeInteger, Default: DataEmbedding.DefaultNumberOfRefinementIterations, Minimum: minimumOf(1),
+		Description: "Number of phase 1 iterations run on each level finer than the coarsest one, including the finest level, at the temperatures of the last iterations of phase 1."},
+	"nearest_neighbours": {Type: FieldTypeObject, DefaultDescription: "exact nearest neighbours",
+		Description: "How the nearest neighbours used for transforming the distances and for building the neighbourhood graph are found."},
+	"nearest_neighbours.method": {Type: FieldTypeString, Default: DataEmbedding.NearestNeighbourMethodExact, AllowedValues: DataEmbedding.NearestNeighbourMethods,
+		Description: "exact partially sorts the distances from every data abstraction unit to every other one in parallel, and nn_descent approximates the nearest neighbours from the input coordinates, or the 30-dimensional UMAP coordinates when preliminary_to_thirty_dimensions_umap is true, by repeatedly comparing the neighbours of neighbours."},
+	"nearest_neighbours.maximum_number_of_iterations": {Type: FieldTypeInteger, Default: DataEmbedding.DefaultMaximumNumberOfNNDescentIterations, Minimum: minimumOf(1),
+		Description: "Maximum number of iterations of the nn_descent method."},
+	"nearest_neighbours.termination_fraction": {Type: FieldTypeNumber, Default: DataEmbedding.DefaultNNDescentTerminationFraction,
+		Description: "The nn_descent method stops once an iteration changes fewer neighbours than this fraction, greater than 0 and less than 1, of all neighbours."},
+	"distance_storage": {Type: FieldTypeString, Default: DataEmbedding.DistanceStorageDense, AllowedValues: DataEmbedding.DistanceStorages,
+		Description: "dense keeps the distances between every pair of data abstraction units in memory, and sparse, which cannot be used when is_input_file_distances is true, only keeps the distances after transformation from every data abstraction unit to its neighbours and computes the others from the coordinates when needed, so that memory grows linearly with the number of data abstraction units. number_of_neighbours_for_building_neighbourhood_graph must then be set. The maximum distance after transformation is then estimated from a sample of pairs."},
+	"parameter_sweep": {Type: FieldTypeObject, ExcludedFieldNames: []string{"stability_analysis"},
+		Description: "Runs the embedding once for every combination of the listed values, each in its own subdirectory of output_directory, and writes a ranked report."},
+	"parameter_sweep.visual_density_adjustment_parameter": {Type: FieldTypeListOfValuesOrRanges,
+		Description: "Values of visual_density_adjustment_parameter, as numbers or start:stop:step ranges."},
+	"parameter_sweep.number_of_neighbours_for_building_neighbourhood_graph": {Type: FieldTypeListOfIntegersOrRanges,
+		Description: "Values of number_of_neighbours_for_building_neighbourhood_graph, as integers or start:stop[:step] ranges."},
+	"parameter_sweep.random_seed": {Type: FieldTypeListOfIntegersOrRanges,
+		Description: "Values of random_seed, as integers or start:stop[:step] ranges."},
+	"stability_analysis": {Type: FieldTypeObject, ExcludedFieldNames: []string{"parameter_sweep"},
+		Description: "Runs the embedding once for every random seed and writes the positional variance and layer agreement of the data abstraction units."},
+	"stability_analysis.random_seeds": {Type: FieldTypeListOfIntegersOrRanges,
+		Description: "At least two random seeds, as integers or start:stop[:step] ranges."},
+}
+
+func DescribeEmbeddingSpecificationsFields() ([]FieldDescription, error) {
+	return describeFields(reflect.TypeOf(EmbeddingSpecifications{}), "")
+}
+
+func describeFields(objectType reflect.Type, fieldNamePrefix string) ([]FieldDescription, error) {
+	fieldDescriptions := make([]FieldDescription, 0, objectType.NumField())
+	for i := 0; i < objectType.NumField(); i++ {
+		fieldName := strings.Split(objectType.Field(i).Tag.Get("json"), ",")[0]
+		if fieldName == "" || fieldName == "-" {
+			continue
+		}
+
+		fieldDescription, exists := embeddingSpecificationFieldDescriptions[fieldNamePrefix+fieldName]
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrorHandling.ErrUndescribedField, fieldNamePrefix+fieldName)
+		}
+		fieldDescription.FieldName = fieldName
+
+		fieldType := objectType.Field(i).Type
+		for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			if fieldNamePrefix == "" && fieldName == "defaults" {
+				fieldDescriptions = append(fieldDescriptions, fieldDescription)
+				continue
+			}
+			objectFieldNamePrefix := fieldNamePrefix + fieldName + "."
+			if fieldNamePrefix == "" && fieldName == "embedding_specifications" {
+				objectFieldNamePrefix = ""
+			}
+			fieldDescriptionsOfObject, err := describeFields(fieldType, objectFieldNamePrefix)
+			if err != nil {
+				return nil, err
+			}
+			fieldDescription.FieldDescriptionsOfObject = fieldDescriptionsOfObject
+		}
+
+		fieldDescriptions = append(fieldDescriptions, fieldDescription)
+	}
+	return fieldDescriptions, nil
+}
+
+func StructureHelp() (string, error) {
+	fieldDescriptions, err := DescribeEmbeddingSpecificationsFields()
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Structure of embedding specification file which is a JSON file where some of the JSON elements have default values and paths are relative to the directory containing the embedding specification file:\n")
+	builder.WriteString("The same structure can be written as a YAML file (.yaml or .yml) or a TOML file (.toml), chosen by file extension.\n")
+
+	writeStructureSkeleton(&builder, fieldDescriptions, 0)
+	builder.WriteString("\n\nFields of an embedding specification:\n")
+	for _, fieldDescription := range fieldDescriptions {
+		if fieldDescription.FieldName == "embedding_specifications" {
+			writeFieldDescriptions(&builder, fieldDescription.FieldDescriptionsOfObject, "")
+		}
+	}
+	builder.WriteString("\nFields of the file:\n")
+	for _, fieldDescription := range fieldDescriptions {
+		if fieldDescription.FieldName != "embedding_specifications" {
+			writeFieldDescriptions(&builder, []FieldDescription{fieldDescription}, "")
+		}
+	}
+	builder.WriteString("\nBooleans, integers and numbers may also be written as strings, for example \"true\" or \"0.9\".")
+
+	return builder.String(), nil
+}
+
+func writeStructureSkeleton(builder *strings.Builder, fieldDescriptions []FieldDescription, depth int) {
+	indentation := strings.Repeat("\t", depth)
+	builder.WriteString("{\n")
+	for i, fieldDescription := range fieldDescriptions {
+		builder.WriteString(indentation + "\t\"" + fieldDescription.FieldName + "\":")
+		switch {
+		case fieldDescription.FieldName == "embedding_specifications" && depth == 0:
+			builder.WriteString("[\n" + indentation + "\t")
+			writeStructureSkeleton(builder, fieldDescription.FieldDescriptionsOfObject, depth+1)
+			builder.WriteString("\n" + indentation + "\t]")
+		case fieldDescription.Type == FieldTypeObject && len(fieldDescription.FieldDescriptionsOfObject) == 0:
+			builder.WriteString("{}")
+		case fieldDescription.Type == FieldTypeObject:
+			writeStructureSkeleton(builder, fieldDescription.FieldDescriptionsOfObject, depth+1)
+		case fieldDescription.FieldName == "spec_version":
+			builder.WriteString(strconv.Itoa(CurrentSpecificationVersion))
+		case strings.HasPrefix(fieldDescription.Type, "list"):
+			builder.WriteString("[]")
+		default:
+			builder.WriteString("\"\"")
+		}
+		if i < len(fieldDescriptions)-1 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString(indentation + "}")
+}
+
+func writeFieldDescriptions(builder *strings.Builder, fieldDescriptions []FieldDescription, fieldNamePrefix string) {
+	for _, fieldDescription := range fieldDescriptions {
+		details := []string{fieldDescription.Type}
+		if fieldDescription.IsRequired && fieldNamePrefix == "" && fieldDescription.Type != FieldTypeObject {
+			details = append(details, "required, can be inherited")
+		} else if fieldDescription.IsRequired {
+			details = append(details, "required")
+		} else if defaultValue := describeDefault(fieldDescription); defaultValue != "" {
+			details = append(details, "default: "+defaultValue)
+		}
+		if fieldDescription.Minimum != nil {
+			details = append(details, "minimum: "+strconv.FormatInt(*fieldDescription.Minimum, 10))
+		}
+		if len(fieldDescription.AllowedValues) > 0 {
+			details = append(details, "one of: "+strings.Join(fieldDescription.AllowedValues, ", "))
+		}
+
+		builder.WriteString("  " + fieldNamePrefix + fieldDescription.FieldName + " (" + strings.Join(details, ", ") + ")\n")
+		builder.WriteString("      " + fieldDescription.Description + "\n")
+		if len(fieldDescription.ExcludedFieldNames) > 0 {
+			builder.WriteString("      Cannot be used together with " + strings.Join(fieldDescription.ExcludedFieldNames, " or ") + ".\n")
+		}
+		if len(fieldDescription.RequiresOneOfFieldNames) > 0 {
+			builder.WriteString("      Can only be used together with " + strings.Join(fieldDescription.RequiresOneOfFieldNames, " or ") + ".\n")
+		}
+
+		writeFieldDescriptions(builder, fieldDescription.FieldDescriptionsOfObject, fieldNamePrefix+fieldDescription.FieldName+".")
+	}
+}
+
+func describeDefault(fieldDescription FieldDescription) string {
+	if fieldDescription.DefaultDescription != "" {
+		return fieldDescription.DefaultDescription
+	}
+	if fieldDescription.Default == nil {
+		return ""
+	}
+	defaultBytes, err := json.Marshal(fieldDescription.Default)
+	if err != nil {
+		return fmt.Sprint(fieldDescription.Default)
+	}
+	return string(defaultBytes)
+}
+
+func JSONSchema() ([]byte, error) {
+	fieldDescriptions, err := DescribeEmbeddingSpecificationsFields()
+	if err != nil {
+		return nil, err
+	}
+
+	var embeddingSpecificationSchema map[string]interface{}
+	for _, fieldDescription := range fieldDescriptions {
+		if fieldDescription.FieldName == "embedding_specifications" {
+			embeddingSpecificationSchema = objectSchema(fieldDescription.FieldDescriptionsOfObject)
+		}
+	}
+
+	schema := objectSchema(fieldDescriptions)
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "Chocolate LVSDE embedding specifications"
+	schema["definitions"] = map[string]interface{}{"embedding_specification": embeddingSpecificationSchema}
+	properties := schema["properties"].(map[string]interface{})
+	properties["spec_version"].(map[string]interface{})["oneOf"].([]interface{})[0].(map[string]interface{})["maximum"] = CurrentSpecificationVersion
+	delete(embeddingSpecificationSchema, "required")
+	properties["defaults"] = map[string]interface{}{
+		"description": embeddingSpecificationFieldDescriptions["defaults"].Description,
+		"$ref":        "#/definitions/embedding_specification",
+	}
+	properties["embedding_specifications"] = map[string]interface{}{
+		"description": embeddingSpecificationFieldDescriptions["embedding_specifications"].Description,
+		"type":        "array",
+		"items":       map[string]interface{}{"$ref": "#/definitions/embedding_specification"},
+	}
+
+	return json.MarshalIndent(schema, "", "\t")
+}
+
+func objectSchema(fieldDescriptions []FieldDescription) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	exclusions := make([]interface{}, 0)
+	dependencies := make(map[string]interface{})
+
+	for _, fieldDescription := range fieldDescriptions {
+		properties[fieldDescription.FieldName] = fieldSchema(fieldDescription)
+		if fieldDescription.IsRequired {
+			required = append(required, fieldDescription.FieldName)
+		}
+		for _, excludedFieldName := range fieldDescription.ExcludedFieldNames {
+			if fieldDescription.FieldName < excludedFieldName {
+				exclusions = append(exclusions, map[string]interface{}{"not": map[string]interface{}{"required": []string{fieldDescription.FieldName, excludedFieldName}}})
+			}
+		}
+		if len(fieldDescription.RequiresOneOfFieldNames) > 0 {
+			alternatives := make([]interface{}, 0, len(fieldDescription.RequiresOneOfFieldNames))
+			for _, requiredFieldName := range fieldDescription.RequiresOneOfFieldNames {
+				alternatives = append(alternatives, map[string]interface{}{"required": []string{requiredFieldName}})
+			}
+			dependencies[fieldDescription.FieldName] = map[string]interface{}{"anyOf": alternatives}
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if len(exclusions) > 0 {
+		schema["allOf"] = exclusions
+	}
+	if len(dependencies) > 0 {
+		schema["dependencies"] = dependencies
+	}
+	return schema
+}
+
+func fieldSchema(fieldDescription FieldDescription) map[string]interface{} {
+	var schema map[string]interface{}
+
+	switch fieldDescription.Type {
+	case FieldTypeObject:
+		schema = objectSchema(fieldDescription.FieldDescriptionsOfObject)
+	case FieldTypeBoolean, FieldTypeInteger, FieldTypeNumber:
+		schema = scalarSchema(fieldDescription.Type, fieldDescription.Minimum)
+	case FieldTypeListOfStrings:
+		schema = map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": []string{"string", "number"}}}
+	case FieldTypeListOfColours:
+		schema = map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string", "pattern": "^#[0-9A-Fa-f]{6}$"}}
+	case FieldTypeListOfIntegers:
+		schema = map[string]interface{}{"type": "array", "items": scalarSchema(FieldTypeInteger, fieldDescription.Minimum)}
+	case FieldTypeListOfValuesOrRanges:
+		schema = map[string]interface{}{"type": "array", "minItems": 1, "items": map[string]interface{}{"type": []string{"number", "string"}}}
+	case FieldTypeListOfIntegersOrRanges:
+		schema = map[string]interface{}{"type": "array", "minItems": 1, "items": map[string]interface{}{"oneOf": []interface{}{
+			map[string]interface{}{"type": "integer"},
+			map[string]interface{}{"type": "string", "pattern": "^\\s*-?[0-9]+\\s*(:\\s*-?[0-9]+\\s*(:\\s*-?[0-9]+\\s*)?)?$"},
+		}}}
+	default:
+		schema = map[string]interface{}{"type": "string"}
+		if len(fieldDescription.AllowedValues) > 0 {
+			schema["enum"] = fieldDescription.AllowedValues
+		}
+	}
+
+	description := fieldDescription.Description
+	if fieldDescription.DefaultDescription != "" {
+		description += " Default: " + fieldDescription.DefaultDescription + "."
+	}
+	schema["description"] = description
+	if fieldDescription.Default != nil && fieldDescription.DefaultDescription == "" {
+		schema["default"] = fieldDescription.Default
+	}
+	return schema
+}
+
+func scalarSchema(fieldType string, minimum *int64) map[string]interface{} {
+	var nativeSchema map[string]interface{}
+	var stringSchema map[string]interface{}
+
+	switch fieldType {
+	case FieldTypeBoolean:
+		nativeSchema = map[string]interface{}{"type": "boolean"}
+		stringSchema = map[string]interface{}{"type": "string", "enum": []string{"true", "false"}}
+	case FieldTypeInteger:
+		nativeSchema = map[string]interface{}{"type": "integer"}
+		if minimum != nil {
+			nativeSchema["minimum"] = *minimum
+		}
+		stringSchema = map[string]interface{}{"type": "string", "pattern": "^-?[0-9]+$"}
+	default:
+		nativeSchema = map[string]interface{}{"type": "number"}
+		stringSchema = map[string]interface{}{"type": "string", "pattern": "^[-+]?([0-9]+\\.?[0-9]*|\\.[0-9]+)([eE][-+]?[0-9]+)?$"}
+	}
+
+	return map[string]interface{}{"oneOf": []interface{}{nativeSchema, stringSchema}}
+}
@@ -0,0 +1,356 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"gopkg.in/yaml.v3"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const CurrentSpecificationVersion = 1
+
+const (
+	SpecificationFileFormatJSON = "JSON"
+	SpecificationFileFormatYAML = "YAML"
+	SpecificationFileFormatTOML = "TOML"
+)
+
+type specificationMigration func(embeddingSpecifications map[string]interface{}) error
+
+var specificationMigrations = []specificationMigration{}
+
+type specificationNormalisation struct {
+	problems []ValidationProblem
+}
+
+func (specificationNormalisation *specificationNormalisation) addProblem(specificationIndex int, fieldName string, err error, message string) {
+	specificationNormalisation.problems = append(specificationNormalisation.problems, ValidationProblem{SpecificationIndex: specificationIndex, FieldName: fieldName, Message: message, Err: err})
+}
+
+func SpecificationFileFormatOfFile(embeddingSpecificationFilePath string) string {
+	switch strings.ToLower(filepath.Ext(embeddingSpecificationFilePath)) {
+	case ".yaml", ".yml":
+		return SpecificationFileFormatYAML
+	case ".toml":
+		return SpecificationFileFormatTOML
+	}
+	return SpecificationFileFormatJSON
+}
+
+func decodeEmbeddingSpecifications(embeddingSpecificationFileBytes []byte, specificationFileFormat string) (EmbeddingSpecifications, []ValidationProblem, error) {
+	var embeddingSpecifications EmbeddingSpecifications
+	var normalisation specificationNormalisation
+	var document interface{}
+
+	switch specificationFileFormat {
+	case SpecificationFileFormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(embeddingSpecificationFileBytes))
+		err := decoder.Decode(&document)
+		if err != nil && err != io.EOF {
+			normalisation.addProblem(-1, "", ErrorHandling.ErrUnparsableSpecification, "Could not parse the embedding specifications file. "+err.Error())
+			normalisation.problems[0].RowNumber = lineNumberOfYAMLError(err)
+			return embeddingSpecifications, normalisation.problems, nil
+		}
+		var nextDocument interface{}
+		if err == nil && decoder.Decode(&nextDocument) != io.EOF {
+			normalisation.addProblem(-1, "", ErrorHandling.ErrUnparsableSpecification, "Could not parse the embedding specifications file. There is more than one YAML document.")
+			return embeddingSpecifications, normalisation.problems, nil
+		}
+		document = jsonCompatibleValue(document)
+	case SpecificationFileFormatTOML:
+		var tomlDocument map[string]interface{}
+		err := toml.Unmarshal(embeddingSpecificationFileBytes, &tomlDocument)
+		if err != nil {
+			normalisation.addProblem(-1, "", ErrorHandling.ErrUnparsableSpecification, "Could not parse the embedding specifications file. "+err.Error())
+			var parseError toml.ParseError
+			if errors.As(err, &parseError) {
+				normalisation.problems[0].RowNumber = parseError.Position.Line
+			}
+			return embeddingSpecifications, normalisation.problems, nil
+		}
+		document = jsonCompatibleValue(tomlDocument)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(embeddingSpecificationFileBytes))
+		decoder.UseNumber()
+
+		err := decoder.Decode(&document)
+		if err != nil {
+			return embeddingSpecifications, nil, err
+		}
+
+		if _, err = decoder.Token(); err != io.EOF {
+			normalisation.addProblem(-1, "", ErrorHandling.ErrUnparsableSpecification, "Could not parse the embedding specifications file. There is unexpected data after the top-level JSON object.")
+			normalisation.problems[0].RowNumber = lineNumberOfOffset(embeddingSpecificationFileBytes, decoder.InputOffset())
+			return embeddingSpecifications, normalisation.problems, nil
+		}
+	}
+
+	documentObject, isObject := document.(map[string]interface{})
+	if !isObject {
+		normalisation.addProblem(-1, "", ErrorHandling.ErrUnparsableSpecification, "The embedding specifications file must contain a "+specificationFileFormat+" object with an \"embedding_specifications\" list.")
+		return embeddingSpecifications, normalisation.problems, nil
+	}
+
+	specificationVersion, problem := migrateEmbeddingSpecifications(documentObject)
+	if problem != nil {
+		return embeddingSpecifications, []ValidationProblem{*problem}, nil
+	}
+
+	normalisedDocument := normalisation.normaliseObject(documentObject, reflect.TypeOf(embeddingSpecifications), -1, "")
+	if len(normalisation.problems) > 0 {
+		return embeddingSpecifications, normalisation.problems, nil
+	}
+
+	problems := mergeInheritedEmbeddingSpecifications(normalisedDocument)
+	if len(problems) > 0 {
+		return embeddingSpecifications, problems, nil
+	}
+
+	normalisedBytes, err := json.Marshal(normalisedDocument)
+	if err == nil {
+		err = json.Unmarshal(normalisedBytes, &embeddingSpecifications)
+	}
+	if err != nil {
+		normalisation.addProblem(-1, "", ErrorHandling.ErrUnparsableSpecification, "Could not parse the embedding specifications file. "+err.Error())
+		return embeddingSpecifications, normalisation.problems, nil
+	}
+
+	embeddingSpecifications.SpecVersion = specificationVersion
+	return embeddingSpecifications, nil, nil
+}
+
+func jsonCompatibleValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(typedValue))
+		for key, item := range typedValue {
+			object[key] = jsonCompatibleValue(item)
+		}
+		return object
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(typedValue))
+		for key, item := range typedValue {
+			object[fmt.Sprint(key)] = jsonCompatibleValue(item)
+		}
+		return object
+	case []interface{}:
+		list := make([]interface{}, len(typedValue))
+		for i, item := range typedValue {
+			list[i] = jsonCompatibleValue(item)
+		}
+		return list
+	case []map[string]interface{}:
+		list := make([]interface{}, len(typedValue))
+		for i, item := range typedValue {
+			list[i] = jsonCompatibleValue(item)
+		}
+		return list
+	case int:
+		return json.Number(strconv.Itoa(typedValue))
+	case int64:
+		return json.Number(strconv.FormatInt(typedValue, 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(typedValue, 10))
+	case float64:
+		return json.Number(strconv.FormatFloat(typedValue, 'g', -1, 64))
+	}
+	return value
+}
+
+func lineNumberOfYAMLError(err error) int {
+	var lineNumber int
+	message := err.Error()
+	if index := strings.Index(message, "line "); index >= 0 {
+		fmt.Sscanf(message[index:], "line %d", &lineNumber)
+	}
+	return lineNumber
+}
+
+func migrateEmbeddingSpecifications(documentObject map[string]interface{}) (int, *ValidationProblem) {
+	specificationVersion := 1
+
+	if value, exists := documentObject["spec_version"]; exists && value != nil {
+		version, err := strconv.Atoi(strings.TrimSpace(fmt.Sprint(value)))
+		if err != nil || version < 1 {
+			return 0, &ValidationProblem{SpecificationIndex: -1, FieldName: "spec_version", Message: fmt.Sprintf("%v is not a positive integer", value), Err: ErrorHandling.ErrUnparsableSpecification}
+		}
+		if version > CurrentSpecificationVersion {
+			return 0, &ValidationProblem{SpecificationIndex: -1, FieldName: "spec_version", Message: fmt.Sprintf("is %d but this version of Chocolate LVSDE only reads versions up to %d, a newer version of Chocolate LVSDE is needed", version, CurrentSpecificationVersion), Err: ErrorHandling.ErrUnparsableSpecification}
+		}
+		specificationVersion = version
+	}
+	delete(documentObject, "spec_version")
+
+	for ; specificationVersion < CurrentSpecificationVersion; specificationVersion++ {
+		err := specificationMigrations[specificationVersion-1](documentObject)
+		if err != nil {
+			return 0, &ValidationProblem{SpecificationIndex: -1, FieldName: "spec_version", Message: fmt.Sprintf("could not migrate from version %d to version %d: %s", specificationVersion, specificationVersion+1, err.Error()), Err: ErrorHandling.ErrUnparsableSpecification}
+		}
+	}
+
+	return specificationVersion, nil
+}
+
+func (specificationNormalisation *specificationNormalisation) normaliseObject(object map[string]interface{}, objectType reflect.Type, specificationIndex int, fieldNamePrefix string) map[string]interface{} {
+	fieldTypes := make(map[string]reflect.Type)
+	fieldNames := make([]string, 0, objectType.NumField())
+	for i := 0; i < objectType.NumField(); i++ {
+		fieldName := strings.Split(objectType.Field(i).Tag.Get("json"), ",")[0]
+		if fieldName == "" || fieldName == "-" {
+			continue
+		}
+		fieldTypes[fieldName] = objectType.Field(i).Type
+		fieldNames = append(fieldNames, fieldName)
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	normalisedObject := make(map[string]interface{})
+	for _, key := range keys {
+		fieldType, isKnown := fieldTypes[key]
+		if !isKnown {
+			message := "is not a known field"
+			if suggestion := closestFieldName(key, fieldNames); suggestion != "" {
+				message += ", did you mean \"" + suggestion + "\"?"
+			}
+			specificationNormalisation.addProblem(specificationIndex, fieldNamePrefix+key, ErrorHandling.ErrUnparsableSpecification, message)
+			continue
+		}
+
+		if fieldNamePrefix == "" && key == "embedding_specifications" {
+			list, isList := object[key].([]interface{})
+			if !isList {
+				specificationNormalisation.addProblem(-1, key, ErrorHandling.ErrUnparsableSpecification, "must be a list of embedding specifications")
+				continue
+			}
+			normalisedList := make([]interface{}, len(list))
+			for i, item := range list {
+				normalisedList[i] = specificationNormalisation.normaliseValue(item, fieldType.Elem(), i, "")
+			}
+			normalisedObject[key] = normalisedList
+			continue
+		}
+
+		normalisedObject[key] = specificationNormalisation.normaliseValue(object[key], fieldType, specificationIndex, fieldNamePrefix+key)
+	}
+
+	return normalisedObject
+}
+
+func (specificationNormalisation *specificationNormalisation) normaliseValue(value interface{}, valueType reflect.Type, specificationIndex int, fieldName string) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	switch valueType.Kind() {
+	case reflect.Ptr:
+		return specificationNormalisation.normaliseValue(value, valueType.Elem(), specificationIndex, fieldName)
+	case reflect.Struct:
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			specificationNormalisation.addProblem(specificationIndex, fieldName, ErrorHandling.ErrUnparsableSpecification, "must be a JSON object")
+			return nil
+		}
+		fieldNamePrefix := ""
+		if fieldName != "" {
+			fieldNamePrefix = fieldName + "."
+		}
+		return specificationNormalisation.normaliseObject(object, valueType, specificationIndex, fieldNamePrefix)
+	case reflect.Slice:
+		list, isList := value.([]interface{})
+		if !isList {
+			specificationNormalisation.addProblem(specificationIndex, fieldName, ErrorHandling.ErrUnparsableSpecification, "must be a list")
+			return nil
+		}
+		normalisedList := make([]interface{}, len(list))
+		for i, item := range list {
+			normalisedList[i] = specificationNormalisation.normaliseValue(item, valueType.Elem(), specificationIndex, fieldName+"["+strconv.Itoa(i)+"]")
+		}
+		return normalisedList
+	case reflect.String:
+		switch typedValue := value.(type) {
+		case string:
+			return typedValue
+		case json.Number:
+			return typedValue.String()
+		case bool:
+			return strconv.FormatBool(typedValue)
+		}
+		specificationNormalisation.addProblem(specificationIndex, fieldName, ErrorHandling.ErrUnparsableSpecification, "must be a string, a number or a boolean")
+		return nil
+	}
+
+	specificationNormalisation.addProblem(specificationIndex, fieldName, ErrorHandling.ErrUnparsableSpecification, "has an unsupported type")
+	return nil
+}
+
+func closestFieldName(fieldName string, fieldNames []string) string {
+	closestFieldName := ""
+	closestDistance := len(fieldName)/3 + 1
+	for _, candidate := range fieldNames {
+		distance := editDistance(strings.ToLower(fieldName), candidate)
+		if distance < closestDistance || (closestFieldName == "" && strings.Contains(candidate, strings.ToLower(fieldName)) && len(fieldName) >= 4) {
+			closestFieldName = candidate
+			closestDistance = distance
+		}
+	}
+	return closestFieldName
+}
+
+func editDistance(a string, b string) int {
+	previousRow := make([]int, len(b)+1)
+	currentRow := make([]int, len(b)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		currentRow[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitutionCost := 1
+			if a[i-1] == b[j-1] {
+				substitutionCost = 0
+			}
+			currentRow[j] = previousRow[j-1] + substitutionCost
+			if previousRow[j]+1 < currentRow[j] {
+				currentRow[j] = previousRow[j] + 1
+			}
+			if currentRow[j-1]+1 < currentRow[j] {
+				currentRow[j] = currentRow[j-1] + 1
+			}
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(b)]
+}
@@ -0,0 +1,168 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"context"
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/FileReadingOrWriting"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const StabilityAnalysisDirectoryName = "stability"
+
+type StabilityAnalysis struct {
+	RandomSeeds []string `json:"random_seeds"`
+}
+
+type StabilitySummary struct {
+	RandomSeeds            []int64 `json:"random_seeds"`
+	ReferenceRandomSeed    int64   `json:"reference_random_seed"`
+	PositionalVarianceUnit string  `json:"positional_variance_unit"`
+	*DataEmbedding.LayoutStability
+}
+
+func parameterSweepOfStabilityAnalysis(embeddingSpecification EmbeddingSpecification) EmbeddingSpecification {
+	embeddingSpecification.ParameterSweep = &ParameterSweep{RandomSeeds: embeddingSpecification.StabilityAnalysis.RandomSeeds}
+	embeddingSpecification.StabilityAnalysis = nil
+	return embeddingSpecification
+}
+
+func toStabilityAnalysisProblems(problems []ValidationProblem) []ValidationProblem {
+	for i := range problems {
+		if strings.HasPrefix(problems[i].FieldName, "parameter_sweep") {
+			problems[i].FieldName = "stability_analysis.random_seeds"
+		}
+		problems[i].Message = strings.Replace(problems[i].Message, "run seed_", "seed ", 1)
+	}
+	return problems
+}
+
+func validateStabilityAnalysis(embeddingSpecification EmbeddingSpecification, outputDirectoryPolicy string) []ValidationProblem {
+	if embeddingSpecification.ParameterSweep != nil {
+		return []ValidationProblem{{SpecificationIndex: -1, FieldName: "stability_analysis", Message: "cannot be used together with parameter_sweep", Err: ErrorHandling.ErrInconsistentSpecification}}
+	}
+
+	parameterSweepEmbeddingSpecification := parameterSweepOfStabilityAnalysis(embeddingSpecification)
+	problems := toStabilityAnalysisProblems(validateParameterSweep(parameterSweepEmbeddingSpecification, outputDirectoryPolicy))
+	if len(problems) > 0 {
+		return problems
+	}
+
+	parameterSweepRuns, _ := ExpandParameterSweep(parameterSweepEmbeddingSpecification)
+	if len(parameterSweepRuns) < 2 {
+		problems = append(problems, ValidationProblem{SpecificationIndex: -1, FieldName: "stability_analysis.random_seeds", Message: fmt.Sprintf("has %d distinct random seeds but at least 2 are needed", len(parameterSweepRuns)), Err: ErrorHandling.ErrInconsistentSpecification})
+	}
+	return problems
+}
+
+func runStabilityAnalysis(ctx context.Context, embeddingSpecification EmbeddingSpecification, resolvedEmbeddingSpecification ResolvedEmbeddingSpecification) (EmbeddingSpecificationRunResult, error) {
+	var runResult EmbeddingSpecificationRunResult
+	runResult.OutputDirectory = resolvedEmbeddingSpecification.OutputDirectory
+
+	problems := validateStabilityAnalysis(embeddingSpecification, "")
+	if len(problems) > 0 {
+		return runResult, problems[0]
+	}
+
+	parameterSweepEmbeddingSpecification := parameterSweepOfStabilityAnalysis(embeddingSpecification)
+	parameterSweepRuns, _ := ExpandParameterSweep(parameterSweepEmbeddingSpecification)
+
+	runResult, err := runParameterSweep(ctx, parameterSweepEmbeddingSpecification, resolvedEmbeddingSpecification)
+	if err != nil {
+		return runResult, err
+	}
+
+	parameterSweepRunResultsByName := make(map[string]ParameterSweepRunResult)
+	for _, parameterSweepRunResult := range runResult.ParameterSweepRunResults {
+		parameterSweepRunResultsByName[parameterSweepRunResult.Name] = parameterSweepRunResult
+	}
+
+	Logging.SetStage("stability")
+	Logging.Info("Analysing layout stability...", Logging.Fields{"number_of_random_seeds": len(parameterSweepRuns)})
+
+	var stabilitySummary StabilitySummary
+	layouts := make([][]*DataAbstraction.DataAbstractionUnitVisibility, 0, len(parameterSweepRuns))
+	for _, parameterSweepRun := range parameterSweepRuns {
+		parameterSweepRunResult := parameterSweepRunResultsByName[parameterSweepRun.Name]
+		embeddingDetails, err := FileReadingOrWriting.ReadEmbeddingDetailsFromFile(filepath.Join(parameterSweepRunResult.OutputDirectory, "last_iteration.json"))
+		if err != nil {
+			return runResult, err
+		}
+		layouts = append(layouts, embeddingDetails.EmbeddingIterations[0])
+		stabilitySummary.RandomSeeds = append(stabilitySummary.RandomSeeds, parameterSweepRunResult.RandomSeed)
+	}
+
+	stabilitySummary.LayoutStability, err = DataEmbedding.AnalyseLayoutStability(layouts)
+	if err != nil {
+		return runResult, err
+	}
+	stabilitySummary.ReferenceRandomSeed = stabilitySummary.RandomSeeds[0]
+	stabilitySummary.PositionalVarianceUnit = "squared visual space distance in the layout of the reference random seed, after Procrustes alignment"
+
+	stabilityDirectory := filepath.Join(resolvedEmbeddingSpecification.OutputDirectory, StabilityAnalysisDirectoryName)
+	err = os.MkdirAll(stabilityDirectory, FileReadingOrWriting.Chmod)
+	if err != nil {
+		return runResult, ErrorHandling.NewFileError(stabilityDirectory, ErrorHandling.ErrFileWrite, err.Error())
+	}
+
+	err = writeStabilityPerDataAbstractionUnitFile(filepath.Join(stabilityDirectory, "stability_per_data_abstraction_unit.csv"), stabilitySummary.LayoutStability)
+	if err != nil {
+		return runResult, err
+	}
+
+	err = FileReadingOrWriting.WriteJsonFile(filepath.Join(stabilityDirectory, "stability_summary.json"), stabilitySummary)
+	if err != nil {
+		return runResult, err
+	}
+
+	err = FileReadingOrWriting.WriteValueColouredPositionsToFile(stabilitySummary.MeanPositions, stabilitySummary.PositionalVariances, filepath.Join(stabilityDirectory, "stability_positional_variance.png"))
+	if err != nil {
+		return runResult, err
+	}
+
+	Logging.Info("Layout stability analysis finished.", Logging.Fields{
+		"mean_procrustes_disparity":                               stabilitySummary.MeanProcrustesDisparity,
+		"mean_positional_variance":                                stabilitySummary.MeanPositionalVariance,
+		"fraction_of_data_abstraction_units_with_agreeing_layers": stabilitySummary.FractionOfDataAbstractionUnitsWithAgreeingLayers,
+	})
+
+	return runResult, nil
+}
+
+func writeStabilityPerDataAbstractionUnitFile(filePath string, layoutStability *DataEmbedding.LayoutStability) error {
+	csv := strings.Builder{}
+	csv.WriteString("Data_abstraction_unit_number,Class_label_number,Positional_variance,Gray_layer_frequency,Mean_aligned_x,Mean_aligned_y\r\n")
+	for i := range layoutStability.DataAbstractionUnitNumbers {
+		csv.WriteString(strconv.Itoa(int(layoutStability.DataAbstractionUnitNumbers[i])) + "," +
+			strconv.Itoa(int(layoutStability.ClassLabelNumbers[i])) + "," +
+			strconv.FormatFloat(layoutStability.PositionalVariances[i], 'g', -1, 64) + "," +
+			strconv.FormatFloat(layoutStability.GrayLayerFrequencies[i], 'f', -1, 64) + "," +
+			strconv.FormatFloat(layoutStability.MeanPositions[i][0], 'g', -1, 64) + "," +
+			strconv.FormatFloat(layoutStability.MeanPositions[i][1], 'g', -1, 64) + "\r\n")
+	}
+
+	return FileReadingOrWriting.WriteFile(filePath, []byte(csv.String()))
+}
@@ -0,0 +1,653 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package EmbeddingSpecification
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const MinimumNumberOfDataAbstractionUnits = 21
+
+const maximumNumberOfProblemsReportedPerFile = 10
+
+type ValidationProblem struct {
+	SpecificationIndex int
+	FieldName          string
+	FilePath           string
+	RowNumber          int
+	Message            string
+	Err                error
+}
+
+func (validationProblem ValidationProblem) Error() string {
+	return validationProblem.String()
+}
+
+func (validationProblem ValidationProblem) Unwrap() error {
+	return validationProblem.Err
+}
+
+func (validationProblem ValidationProblem) String() string {
+	location := make([]string, 0)
+	if validationProblem.SpecificationIndex >= 0 {
+		location = append(location, "embedding specification "+strconv.Itoa(validationProblem.SpecificationIndex))
+	}
+	if validationProblem.FieldName != "" {
+		location = append(location, "field \""+validationProblem.FieldName+"\"")
+	}
+	if validationProblem.FilePath != "" {
+		location = append(location, "file "+validationProblem.FilePath)
+	}
+	if validationProblem.RowNumber > 0 {
+		location = append(location, "row "+strconv.Itoa(validationProblem.RowNumber))
+	}
+	if len(location) == 0 {
+		return validationProblem.Message
+	}
+	return strings.Join(location, ", ") + ": " + validationProblem.Message
+}
+
+type ResolvedEmbeddingSpecification struct {
+	InputFilePath                                   string                                      `json:"input_file_path"`
+	IsInputFileDistances                            bool                                        `json:"is_input_file_distances"`
+	OutputDirectory                                 string                                      `json:"output_directory"`
+	ClassLabels                                     []string                                    `json:"class_labels"`
+	ColoursList                                     []string                                    `json:"colours_list"`
+	ImagesFileRedGreenBlueChannels                  string                                      `json:"images_file_red_green_blue_channels"`
+	ImagesFileGrayscaleSingleChannel                string                                      `json:"images_file_grayscale_single_channel"`
+	ImagesFileImageWidth                            int32                                       `json:"images_file_image_width"`
+	ImagesFileHasClassLabelNumbers                  bool                                        `json:"images_file_has_class_label_numbers"`
+	RandomSeed                                      int64                                       `json:"random_seed"`
+	RandomState                                     int64                                       `json:"random_state"`
+	MasterSeed                                      *int64                                      `json:"master_seed"`
+	PreliminaryToThirtyDimensionsUMAP               bool                                        `json:"preliminary_to_thirty_dimensions_umap"`
+	NumberOfInitialDataAbstractionUnits             int32                                       `json:"number_of_initial_data_abstraction_units"`
+	NumberOfSecondaryDataAbstractionUnits           int32                                       `json:"number_of_secondary_data_abstraction_units"`
+	VisualDensityAdjustmentParameter                float64                                     `json:"visual_density_adjustment_parameter"`
+	NumberOfNeighboursForBuildingNeighbourhoodGraph int32                                       `json:"number_of_neighbours_for_building_neighbourhood_graph"`
+	EvaluationNeighbourhoodSizes                    []int                                       `json:"evaluation_neighbourhood_sizes"`
+	CompareWithOtherMethods                         bool                                        `json:"compare_with_other_methods"`
+	UseCosineDistanceForInputMultiDimensionalData   bool                                        `json:"use_cosine_distance_for_input_multi_dimensional_data"`
+	OutputDirectoryPolicy                           string                                      `json:"output_directory_policy"`
+	PhaseSchedule                                   DataEmbedding.PhaseSchedule                 `json:"phase_schedule"`
+	CoolingSchedule                                 DataEmbedding.CoolingScheduleConfiguration  `json:"cooling_schedule"`
+	ConvergenceCriterion                            *DataEmbedding.ConvergenceCriterion         `json:"convergence_criterion"`
+	RepulsiveForces                                 DataEmbedding.RepulsiveForceConfiguration   `json:"repulsive_forces"`
+	Multilevel                                      *DataEmbedding.MultilevelConfiguration      `json:"multilevel"`
+	NearestNeighbours                               DataEmbedding.NearestNeighbourConfiguration `json:"nearest_neighbours"`
+	DistanceStorage                                 string                                      `json:"distance_storage"`
+	Seeds                                           DataEmbedding.Seeds                         `json:"-"`
+	SpecificationFilePath                           string                                      `json:"-"`
+}
+
+// ProtectedFilePaths returns the specification and input files, whose directories must never be cleared as output
+// directories.
+func (resolvedEmbeddingSpecification *ResolvedEmbeddingSpecification) ProtectedFilePaths() []string {
+	protectedFilePaths := make([]string, 0, 4)
+	for _, filePath := range []string{resolvedEmbeddingSpecification.SpecificationFilePath, resolvedEmbeddingSpecification.InputFilePath, resolvedEmbeddingSpecification.ImagesFileGrayscaleSingleChannel, resolvedEmbeddingSpecification.ImagesFileRedGreenBlueChannels} {
+		if filePath != "" {
+			protectedFilePaths = append(protectedFilePaths, filePath)
+		}
+	}
+	return protectedFilePaths
+}
+
+func (resolvedEmbeddingSpecification *ResolvedEmbeddingSpecification) HasImagesFile() bool {
+	return resolvedEmbeddingSpecification.ImagesFileGrayscaleSingleChannel != "" || resolvedEmbeddingSpecification.ImagesFileRedGreenBlueChannels != ""
+}
+
+func (resolvedEmbeddingSpecification *ResolvedEmbeddingSpecification) NumberOfEmbeddedDataAbstractionUnits() int32 {
+	if resolvedEmbeddingSpecification.NumberOfSecondaryDataAbstractionUnits != -1 && (resolvedEmbeddingSpecification.PreliminaryToThirtyDimensionsUMAP || resolvedEmbeddingSpecification.CompareWithOtherMethods) {
+		return resolvedEmbeddingSpecification.NumberOfSecondaryDataAbstractionUnits
+	}
+	return resolvedEmbeddingSpecification.NumberOfInitialDataAbstractionUnits
+}
+
+func (resolvedEmbeddingSpecification *ResolvedEmbeddingSpecification) EffectiveNumberOfNeighboursForBuildingNeighbourhoodGraph() int32 {
+	if resolvedEmbeddingSpecification.NumberOfNeighboursForBuildingNeighbourhoodGraph == -1 {
+		return resolvedEmbeddingSpecification.NumberOfInitialDataAbstractionUnits / 3
+	}
+	return resolvedEmbeddingSpecification.NumberOfNeighboursForBuildingNeighbourhoodGraph
+}
+
+func ResolveEmbeddingSpecification(embeddingSpecification EmbeddingSpecification) (ResolvedEmbeddingSpecification, []ValidationProblem) {
+	var resolved ResolvedEmbeddingSpecification
+	problems := make([]ValidationProblem, 0)
+
+	addProblem := func(fieldName string, err error, message string) {
+		problems = append(problems, ValidationProblem{SpecificationIndex: -1, FieldName: fieldName, Message: message, Err: err})
+	}
+
+	resolved.SpecificationFilePath = embeddingSpecification.SpecificationFilePath
+	resolved.InputFilePath = embeddingSpecification.InputFilePath
+	if resolved.InputFilePath == "" {
+		addProblem("input_file_path", ErrorHandling.ErrUnparsableSpecification, "is required")
+	}
+
+	resolved.OutputDirectory = embeddingSpecification.OutputDirectory
+	if resolved.OutputDirectory == "" {
+		addProblem("output_directory", ErrorHandling.ErrUnparsableSpecification, "is required")
+	}
+
+	resolved.OutputDirectoryPolicy = OutputDirectoryPolicyFail
+	if embeddingSpecification.OutputDirectoryPolicy != "" {
+		resolved.OutputDirectoryPolicy = embeddingSpecification.OutputDirectoryPolicy
+		if !IsValidOutputDirectoryPolicy(resolved.OutputDirectoryPolicy) {
+			addProblem("output_directory_policy", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not one of %s", resolved.OutputDirectoryPolicy, outputDirectoryPolicyDescription()))
+		}
+	}
+
+	if embeddingSpecification.IsInputFileDistances == "" {
+		addProblem("is_input_file_distances", ErrorHandling.ErrUnparsableSpecification, "is required and must be \"true\" or \"false\"")
+	} else {
+		resolved.IsInputFileDistances = resolveBoolean(addProblem, "is_input_file_distances", embeddingSpecification.IsInputFileDistances, false)
+	}
+
+	if embeddingSpecification.NumberOfInitialDataAbstractionUnits == "" {
+		addProblem("number_of_initial_data_abstraction_units", ErrorHandling.ErrUnparsableSpecification, "is required")
+	} else {
+		resolved.NumberOfInitialDataAbstractionUnits = int32(resolveInteger(addProblem, "number_of_initial_data_abstraction_units", embeddingSpecification.NumberOfInitialDataAbstractionUnits, 32, MinimumNumberOfDataAbstractionUnits, 0))
+	}
+
+	resolved.NumberOfSecondaryDataAbstractionUnits = int32(resolveInteger(addProblem, "number_of_secondary_data_abstraction_units", embeddingSpecification.NumberOfSecondaryDataAbstractionUnits, 32, MinimumNumberOfDataAbstractionUnits, -1))
+	if resolved.NumberOfSecondaryDataAbstractionUnits > resolved.NumberOfInitialDataAbstractionUnits && resolved.NumberOfInitialDataAbstractionUnits > 0 {
+		addProblem("number_of_secondary_data_abstraction_units", ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("is %d which is more than number_of_initial_data_abstraction_units (%d)", resolved.NumberOfSecondaryDataAbstractionUnits, resolved.NumberOfInitialDataAbstractionUnits))
+	}
+
+	resolved.VisualDensityAdjustmentParameter = DataEmbedding.DefaultVisualDensityAdjustmentParameter
+	if embeddingSpecification.VisualDensityAdjustmentParameter != "" {
+		visualDensityAdjustmentParameter, err := strconv.ParseFloat(embeddingSpecification.VisualDensityAdjustmentParameter, 64)
+		if err != nil || math.IsNaN(visualDensityAdjustmentParameter) || math.IsInf(visualDensityAdjustmentParameter, 0) {
+			addProblem("visual_density_adjustment_parameter", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not a finite number", embeddingSpecification.VisualDensityAdjustmentParameter))
+		} else {
+			resolved.VisualDensityAdjustmentParameter = visualDensityAdjustmentParameter
+		}
+	}
+
+	resolved.PhaseSchedule = resolvePhaseSchedule(addProblem, embeddingSpecification.PhaseSchedule)
+	resolved.CoolingSchedule = resolveCoolingSchedule(addProblem, embeddingSpecification.CoolingSchedule)
+	resolved.ConvergenceCriterion = resolveConvergenceCriterion(addProblem, embeddingSpecification.ConvergenceCriterion)
+	resolved.RepulsiveForces = resolveRepulsiveForces(addProblem, embeddingSpecification.RepulsiveForces)
+	resolved.Multilevel = resolveMultilevel(addProblem, embeddingSpecification.Multilevel, resolved.PhaseSchedule)
+	resolved.NearestNeighbours = resolveNearestNeighbours(addProblem, embeddingSpecification.NearestNeighbours)
+
+	resolved.DistanceStorage = DataEmbedding.DistanceStorageDense
+	if embeddingSpecification.DistanceStorage != "" {
+		resolved.DistanceStorage = embeddingSpecification.DistanceStorage
+		if resolved.DistanceStorage != DataEmbedding.DistanceStorageDense && resolved.DistanceStorage != DataEmbedding.DistanceStorageSparse {
+			addProblem("distance_storage", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not one of %s", resolved.DistanceStorage, strings.Join(DataEmbedding.DistanceStorages, ", ")))
+		} else if resolved.DistanceStorage == DataEmbedding.DistanceStorageSparse && resolved.IsInputFileDistances {
+			addProblem("distance_storage", ErrorHandling.ErrInconsistentSpecification, "cannot be "+DataEmbedding.DistanceStorageSparse+" when the input file contains distances")
+		}
+	}
+
+	resolved.PreliminaryToThirtyDimensionsUMAP = resolveBoolean(addProblem, "preliminary_to_thirty_dimensions_umap", embeddingSpecification.PreliminaryToThirtyDimensionsUMAP, true)
+	resolved.CompareWithOtherMethods = resolveBoolean(addProblem, "compare_with_other_methods", embeddingSpecification.CompareWithOtherMethods, false)
+	resolved.UseCosineDistanceForInputMultiDimensionalData = resolveBoolean(addProblem, "use_cosine_distance_for_input_multi_dimensional_data", embeddingSpecification.UseCosineDistanceForInputMultiDimensionalData, false)
+
+	numberOfEmbeddedDataAbstractionUnits := int64(resolved.NumberOfEmbeddedDataAbstractionUnits())
+
+	resolved.NumberOfNeighboursForBuildingNeighbourhoodGraph = int32(resolveInteger(addProblem, "number_of_neighbours_for_building_neighbourhood_graph", embeddingSpecification.NumberOfNeighboursForBuildingNeighbourhoodGraph, 32, 1, -1))
+	if numberOfEmbeddedDataAbstractionUnits > 0 && int64(resolved.NumberOfNeighboursForBuildingNeighbourhoodGraph) >= numberOfEmbeddedDataAbstractionUnits {
+		addProblem("number_of_neighbours_for_building_neighbourhood_graph", ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("is %d but must be less than the number of embedded data abstraction units (%d)", resolved.NumberOfNeighboursForBuildingNeighbourhoodGraph, numberOfEmbeddedDataAbstractionUnits))
+	}
+	if resolved.DistanceStorage == DataEmbedding.DistanceStorageSparse && resolved.NumberOfNeighboursForBuildingNeighbourhoodGraph == -1 {
+		addProblem("number_of_neighbours_for_building_neighbourhood_graph", ErrorHandling.ErrInconsistentSpecification, "must be set when distance_storage is "+DataEmbedding.DistanceStorageSparse)
+	}
+
+	resolved.EvaluationNeighbourhoodSizes = make([]int, 0, len(embeddingSpecification.EvaluationNeighbourhoodSizes))
+	for i, evaluationNeighbourhoodSize := range embeddingSpecification.EvaluationNeighbourhoodSizes {
+		fieldName := "evaluation_neighbourhood_sizes[" + strconv.Itoa(i) + "]"
+		k := resolveInteger(addProblem, fieldName, evaluationNeighbourhoodSize, 32, 1, -1)
+		if k == -1 {
+			continue
+		}
+		if numberOfEmbeddedDataAbstractionUnits > 0 && k >= numberOfEmbeddedDataAbstractionUnits {
+			addProblem(fieldName, ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("is %d but must be less than the number of embedded data abstraction units (%d)", k, numberOfEmbeddedDataAbstractionUnits))
+			continue
+		}
+		resolved.EvaluationNeighbourhoodSizes = append(resolved.EvaluationNeighbourhoodSizes, int(k))
+	}
+
+	resolved.ColoursList = DefaultColoursList
+	if embeddingSpecification.ColoursList != nil {
+		resolved.ColoursList = embeddingSpecification.ColoursList
+	}
+
+	resolved.ClassLabels = DefaultClassLabels
+	if embeddingSpecification.ClassLabels != nil {
+		resolved.ClassLabels = embeddingSpecification.ClassLabels
+	}
+
+	if embeddingSpecification.ColoursList == nil && embeddingSpecification.ClassLabels != nil {
+		if len(resolved.ClassLabels) > len(resolved.ColoursList) {
+			addProblem("class_labels", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("has %d class labels but colours_list is not specified and there are only %d default colours", len(resolved.ClassLabels), len(resolved.ColoursList)))
+		} else {
+			resolved.ColoursList = resolved.ColoursList[:len(resolved.ClassLabels)]
+		}
+	} else if len(resolved.ColoursList) != len(resolved.ClassLabels) {
+		addProblem("colours_list", ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("has %d colours but there are %d class labels", len(resolved.ColoursList), len(resolved.ClassLabels)))
+	}
+
+	for i, colour := range embeddingSpecification.ColoursList {
+		if !isHexadecimalColour(colour) {
+			addProblem("colours_list["+strconv.Itoa(i)+"]", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not a colour of the form #RRGGBB", colour))
+		}
+	}
+
+	resolved.ImagesFileRedGreenBlueChannels = embeddingSpecification.ImagesFileRedGreenBlueChannels
+	resolved.ImagesFileGrayscaleSingleChannel = embeddingSpecification.ImagesFileGrayscaleSingleChannel
+	if resolved.ImagesFileRedGreenBlueChannels != "" && resolved.ImagesFileGrayscaleSingleChannel != "" {
+		addProblem("images_file_grayscale_single_channel", ErrorHandling.ErrInconsistentSpecification, "cannot be used together with images_file_red_green_blue_channels")
+	}
+
+	resolved.ImagesFileImageWidth = int32(resolveInteger(addProblem, "images_file_image_width", embeddingSpecification.ImagesFileImageWidth, 32, 1, -1))
+	resolved.ImagesFileHasClassLabelNumbers = resolveBoolean(addProblem, "images_file_has_class_label_numbers", embeddingSpecification.ImagesFileHasClassLabelNumbers, false)
+	if !resolved.HasImagesFile() {
+		if embeddingSpecification.ImagesFileImageWidth != "" {
+			addProblem("images_file_image_width", ErrorHandling.ErrInconsistentSpecification, "is specified but there is no images file")
+		}
+		if embeddingSpecification.ImagesFileHasClassLabelNumbers != "" {
+			addProblem("images_file_has_class_label_numbers", ErrorHandling.ErrInconsistentSpecification, "is specified but there is no images file")
+		}
+	}
+
+	if embeddingSpecification.RandomState != "" && embeddingSpecification.RandomSeed != "" {
+		addProblem("random_seed", ErrorHandling.ErrInconsistentSpecification, "cannot be used together with random_state")
+	}
+
+	resolved.RandomState = resolveInteger(addProblem, "random_state", embeddingSpecification.RandomState, 32, 0, 5)
+
+	resolved.RandomSeed = DataEmbedding.DefaultRandomSeed
+	if embeddingSpecification.RandomSeed != "" {
+		randomSeed, err := strconv.ParseInt(embeddingSpecification.RandomSeed, 10, 64)
+		if err != nil {
+			addProblem("random_seed", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not a 64-bit integer", embeddingSpecification.RandomSeed))
+		} else {
+			resolved.RandomSeed = randomSeed
+		}
+	} else if embeddingSpecification.RandomState != "" {
+		resolved.RandomSeed = DataEmbedding.RandomSeedFromRandomState(resolved.RandomState)
+	}
+
+	if embeddingSpecification.MasterSeed != "" {
+		masterSeed, err := strconv.ParseInt(embeddingSpecification.MasterSeed, 10, 64)
+		if err != nil {
+			addProblem("master_seed", ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not a 64-bit integer", embeddingSpecification.MasterSeed))
+		} else {
+			resolved.MasterSeed = &masterSeed
+		}
+	}
+
+	if resolved.MasterSeed != nil {
+		resolved.Seeds = DataEmbedding.DeriveSeedsFromMasterSeed(*resolved.MasterSeed)
+		if embeddingSpecification.RandomSeed != "" {
+			resolved.Seeds.LVSDEInitialisation = resolved.RandomSeed
+		}
+		if embeddingSpecification.RandomState != "" {
+			resolved.Seeds.UMAP = resolved.RandomState
+			resolved.Seeds.TSNE = resolved.RandomState
+		}
+		resolved.RandomSeed = resolved.Seeds.LVSDEInitialisation
+		resolved.RandomState = resolved.Seeds.UMAP
+	} else {
+		resolved.Seeds = DataEmbedding.LegacySeeds(resolved.RandomSeed, resolved.RandomState)
+	}
+
+	return resolved, problems
+}
+
+func resolveBoolean(addProblem func(fieldName string, err error, message string), fieldName string, value string, defaultValue bool) bool {
+	switch value {
+	case "":
+		return defaultValue
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if strings.EqualFold(value, "true") || strings.EqualFold(value, "false") {
+		addProblem(fieldName, ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not \"true\" or \"false\", which are case-sensitive", value))
+		return defaultValue
+	}
+	addProblem(fieldName, ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not \"true\" or \"false\"", value))
+	return defaultValue
+}
+
+func resolveInteger(addProblem func(fieldName string, err error, message string), fieldName string, value string, bitSize int, minimum int64, defaultValue int64) int64 {
+	if value == "" {
+		return defaultValue
+	}
+
+	integer, err := strconv.ParseInt(value, 10, bitSize)
+	if err != nil {
+		addProblem(fieldName, ErrorHandling.ErrUnparsableSpecification, fmt.Sprintf("%q is not a %d-bit integer", value, bitSize))
+		return defaultValue
+	}
+
+	if integer < minimum {
+		addProblem(fieldName, ErrorHandling.ErrInconsistentSpecification, fmt.Sprintf("is %d but must be at least %d", integer, minimum))
+		return defaultValue
+	}
+
+	return integer
+}
+
+func isHexadecimalColour(colour string) bool {
+	if len(colour) != 7 || colour[0] != '#' {
+		return false
+	}
+	_, err := strconv.ParseUint(colour[1:], 16, 32)
+	return err == nil
+}
+
+func readEmbeddingSpecificationsFile(embeddingSpecificationFilePath string) (EmbeddingSpecifications, []ValidationProblem) {
+	var embeddingSpecifications EmbeddingSpecifications
+
+	addProblem := func(rowNumber int, err error, message string) []ValidationProblem {
+		return []ValidationProblem{{SpecificationIndex: -1, FilePath: embeddingSpecificationFilePath, RowNumber: rowNumber, Message: message, Err: err}}
+	}
+
+	_, err := os.Stat(embeddingSpecificationFilePath)
+	if os.IsNotExist(err) {
+		return embeddingSpecifications, addProblem(0, ErrorHandling.ErrFileRead, "Embedding specifications file does not exist.")
+	}
+
+	embeddingSpecificationFileBytes, err := ioutil.ReadFile(embeddingSpecificationFilePath)
+	if err != nil {
+		return embeddingSpecifications, addProblem(0, ErrorHandling.ErrFileRead, "Could not read the embedding specifications file")
+	}
+
+	embeddingSpecifications, problems, err := decodeEmbeddingSpecifications(embeddingSpecificationFileBytes, SpecificationFileFormatOfFile(embeddingSpecificationFilePath))
+
+	var syntaxError *json.SyntaxError
+	if errors.As(err, &syntaxError) {
+		return embeddingSpecifications, addProblem(lineNumberOfOffset(embeddingSpecificationFileBytes, syntaxError.Offset), ErrorHandling.ErrUnparsableSpecification, "Could not parse the embedding specifications file. "+syntaxError.Error())
+	} else if err != nil {
+		return embeddingSpecifications, addProblem(0, ErrorHandling.ErrUnparsableSpecification, "Could not parse the embedding specifications file. "+err.Error())
+	}
+
+	if len(problems) > 0 {
+		for i := range problems {
+			problems[i].FilePath = embeddingSpecificationFilePath
+		}
+		return embeddingSpecifications, problems
+	}
+
+	if len(embeddingSpecifications.EmbeddingSpecifications) == 0 {
+		problems := addProblem(0, ErrorHandling.ErrInconsistentSpecification, "The embedding specifications file has no embedding specifications.")
+		problems[0].FieldName = "embedding_specifications"
+		return embeddingSpecifications, problems
+	}
+
+	specificationDirectory := filepath.Dir(embeddingSpecificationFilePath)
+	for i := 0; i < len(embeddingSpecifications.EmbeddingSpecifications); i++ {
+		embeddingSpecification := &embeddingSpecifications.EmbeddingSpecifications[i]
+		embeddingSpecification.SpecificationFilePath = embeddingSpecificationFilePath
+
+		if embeddingSpecification.InputFilePath != "" {
+			embeddingSpecification.InputFilePath = filepath.Join(specificationDirectory, embeddingSpecification.InputFilePath)
+		}
+
+		if embeddingSpecification.OutputDirectory != "" {
+			embeddingSpecification.OutputDirectory = filepath.Join(specificationDirectory, embeddingSpecification.OutputDirectory)
+		}
+
+		if embeddingSpecification.ImagesFileGrayscaleSingleChannel != "" {
+			embeddingSpecification.ImagesFileGrayscaleSingleChannel = filepath.Join(specificationDirectory, embeddingSpecification.ImagesFileGrayscaleSingleChannel)
+		}
+
+		if embeddingSpecification.ImagesFileRedGreenBlueChannels != "" {
+			embeddingSpecification.ImagesFileRedGreenBlueChannels = filepath.Join(specificationDirectory, embeddingSpecification.ImagesFileRedGreenBlueChannels)
+		}
+	}
+
+	return embeddingSpecifications, nil
+}
+
+func lineNumberOfOffset(bytes []byte, offset int64) int {
+	if offset > int64(len(bytes)) {
+		offset = int64(len(bytes))
+	}
+	return strings.Count(string(bytes[:offset]), "\n") + 1
+}
+
+func ValidateEmbeddingSpecificationsFile(embeddingSpecificationFilePath string, outputDirectoryPolicyOverride string, fieldOverrides []FieldOverride) (EmbeddingSpecifications, []ValidationProblem) {
+	embeddingSpecifications, problems := readEmbeddingSpecificationsFile(embeddingSpecificationFilePath)
+	if len(problems) > 0 {
+		return embeddingSpecifications, problems
+	}
+	OverrideOutputDirectoryPolicy(&embeddingSpecifications, outputDirectoryPolicyOverride)
+	problems = ApplyFieldOverrides(&embeddingSpecifications, fieldOverrides)
+	if len(problems) > 0 {
+		return embeddingSpecifications, problems
+	}
+
+	problems = make([]ValidationProblem, 0)
+	specificationIndicesByOutputDirectory := make(map[string]int)
+
+	for i, embeddingSpecification := range embeddingSpecifications.EmbeddingSpecifications {
+		problems = append(problems, ValidateEmbeddingSpecification(i, embeddingSpecification)...)
+
+		if embeddingSpecification.OutputDirectory == "" {
+			continue
+		}
+		outputDirectory := filepath.Clean(embeddingSpecification.OutputDirectory)
+		if j, exists := specificationIndicesByOutputDirectory[outputDirectory]; exists {
+			problems = append(problems, ValidationProblem{SpecificationIndex: i, FieldName: "output_directory", Message: "is the same as the output directory of embedding specification " + strconv.Itoa(j), Err: ErrorHandling.ErrInconsistentSpecification})
+		} else {
+			specificationIndicesByOutputDirectory[outputDirectory] = i
+		}
+	}
+
+	return embeddingSpecifications, problems
+}
+
+func ValidateEmbeddingSpecification(specificationIndex int, embeddingSpecification EmbeddingSpecification) []ValidationProblem {
+	resolved, problems := ResolveEmbeddingSpecification(embeddingSpecification)
+
+	if embeddingSpecification.StabilityAnalysis != nil {
+		problems = append(problems, validateStabilityAnalysis(embeddingSpecification, resolved.OutputDirectoryPolicy)...)
+	} else if embeddingSpecification.ParameterSweep != nil {
+		problems = append(problems, validateParameterSweep(embeddingSpecification, resolved.OutputDirectoryPolicy)...)
+	} else if resolved.OutputDirectory != "" {
+		if message := existingOutputDirectoryProblem(resolved.OutputDirectory, resolved.OutputDirectoryPolicy); message != "" {
+			problems = append(problems, ValidationProblem{FieldName: "output_directory", FilePath: resolved.OutputDirectory, Message: message, Err: ErrorHandling.ErrOutputDirectoryExists})
+		}
+	}
+
+	maximumClassLabelNumber := len(resolved.ColoursList) - 1
+	if len(resolved.ClassLabels) < len(resolved.ColoursList) {
+		maximumClassLabelNumber = len(resolved.ClassLabels) - 1
+	}
+
+	if resolved.InputFilePath != "" && resolved.NumberOfInitialDataAbstractionUnits > 0 {
+		problems = append(problems, scanInputFile("input_file_path", resolved.InputFilePath, resolved.IsInputFileDistances, int(resolved.NumberOfInitialDataAbstractionUnits), maximumClassLabelNumber)...)
+	}
+
+	if resolved.NumberOfInitialDataAbstractionUnits > 0 {
+		if resolved.ImagesFileGrayscaleSingleChannel != "" {
+			problems = append(problems, scanImagesFile("images_file_grayscale_single_channel", resolved.ImagesFileGrayscaleSingleChannel, 1, resolved.ImagesFileImageWidth, resolved.ImagesFileHasClassLabelNumbers, int(resolved.NumberOfInitialDataAbstractionUnits))...)
+		}
+		if resolved.ImagesFileRedGreenBlueChannels != "" {
+			problems = append(problems, scanImagesFile("images_file_red_green_blue_channels", resolved.ImagesFileRedGreenBlueChannels, 3, resolved.ImagesFileImageWidth, resolved.ImagesFileHasClassLabelNumbers, int(resolved.NumberOfInitialDataAbstractionUnits))...)
+		}
+	}
+
+	for i := range problems {
+		problems[i].SpecificationIndex = specificationIndex
+	}
+
+	return problems
+}
+
+func scanFileRows(fieldName string, filePath string, numberOfRows int, scanRow func(values []string) (string, error)) []ValidationProblem {
+	problems := make([]ValidationProblem, 0)
+
+	fileInformation, err := os.Stat(filePath)
+	if err != nil {
+		return append(problems, ValidationProblem{FieldName: fieldName, FilePath: filePath, Message: "does not exist or cannot be accessed", Err: ErrorHandling.ErrFileRead})
+	}
+	if fileInformation.IsDir() {
+		return append(problems, ValidationProblem{FieldName: fieldName, FilePath: filePath, Message: "is a directory, not a file", Err: ErrorHandling.ErrFileRead})
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return append(problems, ValidationProblem{FieldName: fieldName, FilePath: filePath, Message: "cannot be opened", Err: ErrorHandling.ErrFileRead})
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	rowNumber := 0
+	numberOfRowsRead := 0
+	numberOfUnreportedProblems := 0
+
+	for numberOfRowsRead < numberOfRows {
+		read, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			problems = append(problems, ValidationProblem{FieldName: fieldName, FilePath: filePath, RowNumber: rowNumber + 1, Message: "could not be read: " + err.Error(), Err: ErrorHandling.ErrFileRead})
+			break
+		}
+
+		if len(read) > 0 {
+			rowNumber++
+		}
+
+		read = strings.TrimSpace(read)
+		if len(read) > 0 {
+			numberOfRowsRead++
+			message, err := scanRow(strings.Split(read, ","))
+			if err != nil {
+				if len(problems) < maximumNumberOfProblemsReportedPerFile {
+					problems = append(problems, ValidationProblem{FieldName: fieldName, FilePath: filePath, RowNumber: rowNumber, Message: message, Err: err})
+				} else {
+					numberOfUnreportedProblems++
+				}
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	if numberOfUnreportedProblems > 0 {
+		problems = append(problems, ValidationProblem{FieldName: fieldName, FilePath: filePath, Message: fmt.Sprintf("%d more rows have problems which are not reported", numberOfUnreportedProblems), Err: ErrorHandling.ErrMalformedFile})
+	}
+
+	if numberOfRowsRead < numberOfRows {
+		problems = append(problems, ValidationProblem{FieldName: fieldName, FilePath: filePath, Message: fmt.Sprintf("has %d non-empty rows but %d are needed (number_of_initial_data_abstraction_units)", numberOfRowsRead, numberOfRows), Err: ErrorHandling.ErrMalformedFile})
+	}
+
+	return problems
+}
+
+func scanInputFile(fieldName string, filePath string, isInputFileDistances bool, numberOfDataAbstractionUnits int, maximumClassLabelNumber int) []ValidationProblem {
+	numberOfColumnsOfFirstRow := -1
+
+	return scanFileRows(fieldName, filePath, numberOfDataAbstractionUnits, func(values []string) (string, error) {
+		classLabelNumber, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 32)
+		if err != nil {
+			return fmt.Sprintf("class label number %q in column 1 is not an integer", values[0]), ErrorHandling.ErrMalformedFile
+		}
+		if classLabelNumber < 0 || classLabelNumber > int64(maximumClassLabelNumber) {
+			return fmt.Sprintf("class label number %d is outside the range 0 to %d given by class_labels and colours_list", classLabelNumber, maximumClassLabelNumber), ErrorHandling.ErrNotEnoughColours
+		}
+
+		numberOfValues := len(values) - 1
+		if isInputFileDistances {
+			if numberOfValues < numberOfDataAbstractionUnits {
+				return fmt.Sprintf("has %d distances but %d are needed (number_of_initial_data_abstraction_units)", numberOfValues, numberOfDataAbstractionUnits), ErrorHandling.ErrMalformedFile
+			}
+			numberOfValues = numberOfDataAbstractionUnits
+		} else {
+			if numberOfColumnsOfFirstRow == -1 {
+				numberOfColumnsOfFirstRow = len(values)
+			}
+			if numberOfValues == 0 {
+				return "has a class label number but no coordinates", ErrorHandling.ErrMalformedFile
+			}
+			if len(values) != numberOfColumnsOfFirstRow {
+				return fmt.Sprintf("has %d columns but the first row has %d", len(values), numberOfColumnsOfFirstRow), ErrorHandling.ErrMalformedFile
+			}
+		}
+
+		for i := 1; i <= numberOfValues; i++ {
+			value, err := strconv.ParseFloat(strings.TrimSpace(values[i]), 64)
+			if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+				return fmt.Sprintf("value %q in column %d is not a finite number", values[i], i+1), ErrorHandling.ErrMalformedFile
+			}
+		}
+
+		return "", nil
+	})
+}
+
+func scanImagesFile(fieldName string, filePath string, numberOfChannels int, imageWidth int32, imagesFileHasClassLabelNumbers bool, numberOfDataAbstractionUnits int) []ValidationProblem {
+	return scanFileRows(fieldName, filePath, numberOfDataAbstractionUnits, func(values []string) (string, error) {
+		firstColumn := 1
+		if imagesFileHasClassLabelNumbers {
+			values = values[1:]
+			firstColumn = 2
+		}
+
+		if len(values) == 0 {
+			return "has no pixel values", ErrorHandling.ErrMalformedFile
+		}
+
+		width := int(imageWidth)
+		if imageWidth == -1 {
+			width = int(math.Floor(math.Sqrt(float64(len(values))/float64(numberOfChannels)) + (1e-6)))
+		}
+		height := 0
+		if width > 0 {
+			height = len(values) / (width * numberOfChannels)
+		}
+
+		if imageWidth == -1 && width != height {
+			return fmt.Sprintf("has %d values which is not a square image of %d channel(s) and images_file_image_width is not specified", len(values), numberOfChannels), ErrorHandling.ErrMalformedFile
+		}
+
+		if width*height*numberOfChannels != len(values) || height == 0 {
+			return fmt.Sprintf("has %d values which is not a whole number of rows of width %d with %d channel(s)", len(values), width, numberOfChannels), ErrorHandling.ErrMalformedFile
+		}
+
+		for i, value := range values {
+			number, err := strconv.ParseInt(strings.TrimSpace(value), 10, 16)
+			if err != nil || number < 0 || number > 255 {
+				return fmt.Sprintf("value %q in column %d is not an integer from 0 to 255", value, i+firstColumn), ErrorHandling.ErrMalformedFile
+			}
+		}
+
+		return "", nil
+	})
+}
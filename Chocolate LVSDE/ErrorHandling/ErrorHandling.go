@@ -0,0 +1,108 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package ErrorHandling
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInconsistentSpecification     = errors.New("inconsistent embedding specifications file")
+	ErrUnparsableSpecification       = errors.New("could not parse the embedding specifications file")
+	ErrOutputDirectoryExists         = errors.New("output directory cannot be created because it exists")
+	ErrUnstableFloatingPoint         = errors.New("unstable floating point calculations")
+	ErrNotEnoughColours              = errors.New("not enough colours specified for class label numbers")
+	ErrNotEnoughDataAbstractionUnits = errors.New("not enough data abstraction units")
+	ErrFileRead                      = errors.New("file read error")
+	ErrFileWrite                     = errors.New("file write error")
+	ErrMalformedFile                 = errors.New("malformed file")
+	ErrUnknownFileFormat             = errors.New("unknown file format")
+	ErrInvalidInput                  = errors.New("invalid input data")
+	ErrCancelled                     = errors.New("cancelled")
+	ErrPythonFunctionFailed          = errors.New("python function failed")
+	ErrUndescribedField              = errors.New("field of the embedding specifications has no field description")
+)
+
+type FileRowError struct {
+	FilePath  string
+	RowNumber int
+	Message   string
+	Err       error
+}
+
+func (fileRowError *FileRowError) Error() string {
+	location := []string{"file " + fileRowError.FilePath}
+	if fileRowError.RowNumber > 0 {
+		location = append(location, "row "+strconv.Itoa(fileRowError.RowNumber))
+	}
+	return joinErrorMessage(location, fileRowError.Message, fileRowError.Err)
+}
+
+func (fileRowError *FileRowError) Unwrap() error {
+	return fileRowError.Err
+}
+
+func NewFileError(filePath string, err error, message string) error {
+	return &FileRowError{FilePath: filePath, Message: message, Err: err}
+}
+
+func NewFileRowError(filePath string, rowNumber int, err error, message string) error {
+	return &FileRowError{FilePath: filePath, RowNumber: rowNumber, Message: message, Err: err}
+}
+
+type CancellationError struct {
+	Stage                  string
+	LastCompletedIteration int32
+	Err                    error
+}
+
+func (cancellationError *CancellationError) Error() string {
+	text := "cancelled while " + cancellationError.Stage
+	if cancellationError.LastCompletedIteration > 0 {
+		text += ", last completed iteration " + strconv.Itoa(int(cancellationError.LastCompletedIteration))
+	}
+	if cancellationError.Err != nil {
+		text += ": " + cancellationError.Err.Error()
+	}
+	return text
+}
+
+func (cancellationError *CancellationError) Unwrap() error {
+	return cancellationError.Err
+}
+
+func (cancellationError *CancellationError) Is(target error) bool {
+	return target == ErrCancelled
+}
+
+func NewCancellationError(stage string, lastCompletedIteration int32, err error) error {
+	return &CancellationError{Stage: stage, LastCompletedIteration: lastCompletedIteration, Err: err}
+}
+
+func joinErrorMessage(location []string, message string, err error) string {
+	text := strings.Join(location, ", ")
+	if err != nil {
+		text += ": " + err.Error()
+	}
+	if message != "" {
+		text += ": " + message
+	}
+	return text
+}
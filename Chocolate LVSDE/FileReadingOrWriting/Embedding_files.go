@@ -0,0 +1,341 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package FileReadingOrWriting
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"gopkg.in/mgo.v2/bson"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func WriteZipFile(filePath string, zipEntryName string, bytes []byte) error {
+	zipFile, err := os.Create(filePath)
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	defer zipFile.Close()
+
+	zipWriter := zip.NewWriter(zipFile)
+	var writer io.Writer
+	writer, err = zipWriter.Create(zipEntryName)
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	_, err = writer.Write(bytes)
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	err = zipWriter.Close()
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	return nil
+}
+
+func ReadZipFileEntry(filePath string, zipEntryName string) ([]byte, error) {
+	zipReader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, "could not read the zip file: "+err.Error())
+	}
+	defer zipReader.Close()
+
+	for _, zipEntry := range zipReader.File {
+		if zipEntry.Name != zipEntryName {
+			continue
+		}
+
+		reader, err := zipEntry.Open()
+		if err != nil {
+			return nil, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, "could not read the zip file: "+err.Error())
+		}
+		defer reader.Close()
+
+		bytes, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, "could not read the zip file: "+err.Error())
+		}
+		return bytes, nil
+	}
+
+	return nil, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, "the zip file does not contain "+zipEntryName)
+}
+
+func WriteEmbeddingArchive(filePath string, embeddingDetails *DataAbstraction.EmbeddingDetails) error {
+	bsonBytes, err := bson.Marshal(embeddingDetails)
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	return WriteZipFile(filePath, "archive.bson", bsonBytes)
+}
+
+func ReadEmbeddingArchive(filePath string) (DataAbstraction.EmbeddingDetails, error) {
+	var embeddingDetails DataAbstraction.EmbeddingDetails
+	bsonBytes, err := ReadZipFileEntry(filePath, "archive.bson")
+	if err != nil {
+		return embeddingDetails, err
+	}
+
+	err = bson.Unmarshal(bsonBytes, &embeddingDetails)
+	if err != nil {
+		return embeddingDetails, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, "could not parse the embedding archive")
+	}
+
+	if len(embeddingDetails.ImagesRedGreenBlueChannels) == 0 {
+		embeddingDetails.ImagesRedGreenBlueChannels = nil
+	}
+	if len(embeddingDetails.ImagesGrayscaleSingleChannel) == 0 {
+		embeddingDetails.ImagesGrayscaleSingleChannel = nil
+	}
+	return embeddingDetails, nil
+}
+
+func WriteEmbeddedDataFile(filePath string, embeddedData *DataAbstraction.EmbeddedData) error {
+	bsonBytes, err := bson.Marshal(embeddedData)
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	return WriteZipFile(filePath, "embedded_data.VCED.uncompressed", bsonBytes)
+}
+
+func ReadEmbeddedDataFile(filePath string) (DataAbstraction.EmbeddedData, error) {
+	var embeddedData DataAbstraction.EmbeddedData
+	bsonBytes, err := ReadZipFileEntry(filePath, "embedded_data.VCED.uncompressed")
+	if err != nil {
+		return embeddedData, err
+	}
+
+	err = bson.Unmarshal(bsonBytes, &embeddedData)
+	if err != nil {
+		return embeddedData, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, "could not parse the embedded data file")
+	}
+	return embeddedData, nil
+}
+
+func ReadEmbeddingDetailsFromFile(filePath string) (DataAbstraction.EmbeddingDetails, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".archive":
+		return ReadEmbeddingArchive(filePath)
+	case ".vced":
+		embeddedData, err := ReadEmbeddedDataFile(filePath)
+		if err != nil {
+			return DataAbstraction.EmbeddingDetails{}, err
+		}
+		embeddingDetails, err := embeddedData.ToEmbeddingDetails()
+		if err != nil {
+			return DataAbstraction.EmbeddingDetails{}, ErrorHandling.NewFileError(filePath, err, "")
+		}
+		return *embeddingDetails, nil
+	case ".json":
+		return readEmbeddingDetailsFromJsonFile(filePath)
+	case ".csv":
+		return readEmbeddingDetailsFromCsvFile(filePath)
+	}
+
+	return DataAbstraction.EmbeddingDetails{}, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrUnknownFileFormat, "expected .archive, .VCED, .json or .csv")
+}
+
+func WriteEmbeddingDetailsToFile(filePath string, embeddingDetails *DataAbstraction.EmbeddingDetails, iteration int) error {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".archive":
+		return WriteEmbeddingArchive(filePath, embeddingDetails)
+	case ".vced":
+		return WriteEmbeddedDataFile(filePath, embeddingDetails.ToEmbeddedData())
+	case ".json":
+		return WriteJsonFile(filePath, embeddingDetails)
+	case ".csv":
+		return WriteEmbeddingIterationToCsvFile(filePath, embeddingDetails.EmbeddingIterations[iteration])
+	}
+
+	return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrUnknownFileFormat, "expected .archive, .VCED, .json or .csv")
+}
+
+func WriteJsonFile(filePath string, value interface{}) error {
+	jsonBytes, err := json.MarshalIndent(value, "", "\t")
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	return WriteFile(filePath, jsonBytes)
+}
+
+func FileSHA256(filePath string) (string, int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", 0, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, err.Error())
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	sizeInBytes, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, err.Error())
+	}
+	return hex.EncodeToString(hash.Sum(nil)), sizeInBytes, nil
+}
+
+func WriteFile(filePath string, bytes []byte) error {
+	err := ioutil.WriteFile(filePath, bytes, Chmod)
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	return nil
+}
+
+func WriteEmbeddingIterationToCsvFile(filePath string, dataAbstractionUnitVisibilities []*DataAbstraction.DataAbstractionUnitVisibility) error {
+	var csv strings.Builder
+	csv.WriteString("data_abstraction_unit_number,class_label_number,layer,projection_index,iteration,x,y\r\n")
+	for _, dataAbstractionUnitVisibility := range dataAbstractionUnitVisibilities {
+		for j := 0; j < len(dataAbstractionUnitVisibility.VisualSpaceCoordinates); j++ {
+			csv.WriteString(strconv.Itoa(int(dataAbstractionUnitVisibility.DataAbstractionUnitNumber)) + "," +
+				strconv.Itoa(int(dataAbstractionUnitVisibility.ClassLabelNumber)) + "," +
+				dataAbstractionUnitVisibility.Layer + "," +
+				strconv.Itoa(j) + "," +
+				strconv.Itoa(int(dataAbstractionUnitVisibility.Iteration)) + "," +
+				strconv.FormatFloat(dataAbstractionUnitVisibility.VisualSpaceCoordinates[j][0], 'g', -1, 64) + "," +
+				strconv.FormatFloat(dataAbstractionUnitVisibility.VisualSpaceCoordinates[j][1], 'g', -1, 64) + "\r\n")
+		}
+	}
+
+	return WriteFile(filePath, []byte(csv.String()))
+}
+
+func readEmbeddingDetailsFromJsonFile(filePath string) (DataAbstraction.EmbeddingDetails, error) {
+	var embeddingDetails DataAbstraction.EmbeddingDetails
+
+	jsonBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return embeddingDetails, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, err.Error())
+	}
+
+	trimmedJsonBytes := strings.TrimSpace(string(jsonBytes))
+	if strings.HasPrefix(trimmedJsonBytes, "[") {
+		var dataAbstractionUnitVisibilities []*DataAbstraction.DataAbstractionUnitVisibility
+		err = json.Unmarshal(jsonBytes, &dataAbstractionUnitVisibilities)
+		if err != nil {
+			return embeddingDetails, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, "could not parse the embedding file: "+err.Error())
+		}
+		embeddingDetails.EmbeddingIterations = [][]*DataAbstraction.DataAbstractionUnitVisibility{dataAbstractionUnitVisibilities}
+		return embeddingDetails, nil
+	}
+
+	var jsonObject map[string]json.RawMessage
+	err = json.Unmarshal(jsonBytes, &jsonObject)
+	if err != nil {
+		return embeddingDetails, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, "could not parse the embedding file: "+err.Error())
+	}
+
+	if _, isEmbeddedData := jsonObject["data_instances"]; isEmbeddedData {
+		var embeddedData DataAbstraction.EmbeddedData
+		err = json.Unmarshal(jsonBytes, &embeddedData)
+		if err != nil {
+			return embeddingDetails, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, "could not parse the embedding file: "+err.Error())
+		}
+		convertedEmbeddingDetails, err := embeddedData.ToEmbeddingDetails()
+		if err != nil {
+			return embeddingDetails, ErrorHandling.NewFileError(filePath, err, "")
+		}
+		return *convertedEmbeddingDetails, nil
+	}
+
+	err = json.Unmarshal(jsonBytes, &embeddingDetails)
+	if err != nil {
+		return embeddingDetails, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, "could not parse the embedding file: "+err.Error())
+	}
+	if embeddingDetails.EmbeddingIterations == nil {
+		return embeddingDetails, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, "the embedding file has no embedding iterations")
+	}
+	return embeddingDetails, nil
+}
+
+func readEmbeddingDetailsFromCsvFile(filePath string) (DataAbstraction.EmbeddingDetails, error) {
+	var embeddingDetails DataAbstraction.EmbeddingDetails
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return embeddingDetails, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, err.Error())
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	dataAbstractionUnitVisibilities := make([]*DataAbstraction.DataAbstractionUnitVisibility, 0)
+	dataAbstractionUnitVisibilityIndices := make(map[int32]int)
+	rowNumber := 0
+
+	for {
+		read, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return embeddingDetails, ErrorHandling.NewFileRowError(filePath, rowNumber+1, ErrorHandling.ErrFileRead, err.Error())
+		}
+
+		read = strings.TrimSpace(read)
+		rowNumber++
+		if len(read) == 0 || rowNumber == 1 {
+			if err != nil {
+				break
+			}
+			continue
+		}
+
+		readValues := strings.Split(read, ",")
+		if len(readValues) != 7 {
+			return embeddingDetails, ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrMalformedFile, "expected 7 columns but found "+strconv.Itoa(len(readValues)))
+		}
+
+		dataAbstractionUnitNumber, err1 := strconv.ParseInt(readValues[0], 10, 32)
+		classLabelNumber, err2 := strconv.ParseInt(readValues[1], 10, 32)
+		iteration, err3 := strconv.ParseInt(readValues[4], 10, 32)
+		x, err4 := strconv.ParseFloat(readValues[5], 64)
+		y, err5 := strconv.ParseFloat(readValues[6], 64)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil {
+			return embeddingDetails, ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrMalformedFile, "could not parse the row")
+		}
+
+		index, exists := dataAbstractionUnitVisibilityIndices[int32(dataAbstractionUnitNumber)]
+		if !exists {
+			dataAbstractionUnitVisibility := new(DataAbstraction.DataAbstractionUnitVisibility)
+			dataAbstractionUnitVisibility.DataAbstractionUnitNumber = int32(dataAbstractionUnitNumber)
+			dataAbstractionUnitVisibility.ClassLabelNumber = int32(classLabelNumber)
+			dataAbstractionUnitVisibility.Layer = readValues[2]
+			dataAbstractionUnitVisibility.Iteration = int32(iteration)
+			dataAbstractionUnitVisibility.VisualSpaceCoordinates = make([][2]float64, 0)
+			index = len(dataAbstractionUnitVisibilities)
+			dataAbstractionUnitVisibilityIndices[int32(dataAbstractionUnitNumber)] = index
+			dataAbstractionUnitVisibilities = append(dataAbstractionUnitVisibilities, dataAbstractionUnitVisibility)
+		}
+
+		dataAbstractionUnitVisibilities[index].VisualSpaceCoordinates = append(dataAbstractionUnitVisibilities[index].VisualSpaceCoordinates, [2]float64{x, y})
+
+		if err != nil {
+			break
+		}
+	}
+
+	embeddingDetails.EmbeddingIterations = [][]*DataAbstraction.DataAbstractionUnitVisibility{dataAbstractionUnitVisibilities}
+	return embeddingDetails, nil
+}
@@ -23,14 +23,16 @@ import (
 	"bufio"
 	"fmt"
 	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
 	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/WebUserInterface"
 	"io"
 	"io/fs"
-	"io/ioutil"
 	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -39,16 +41,18 @@ import "github.com/fogleman/gg"
 
 var Chmod fs.FileMode = 0700
 
-func ReadDataAbstractionSetFromDistancesFile(filePath string, numberOfInitialDataAbstractionUnits int32, maximumClassLabelNumber int32) DataAbstraction.DataAbstractionSet {
+func ReadDataAbstractionSetFromDistancesFile(filePath string, numberOfInitialDataAbstractionUnits int32, maximumClassLabelNumber int32) (DataAbstraction.DataAbstractionSet, error) {
 	var dataAbstractionSet DataAbstraction.DataAbstractionSet
 
 	file, err := os.Open(filePath)
 
 	if err != nil {
-		panic("File read error")
+		return dataAbstractionSet, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, err.Error())
 	}
+	defer file.Close()
 
 	reader := bufio.NewReader(file)
+	rowNumber := 0
 
 	var dataAbstractionUnitNumber int32 = -1
 	dataAbstractionSet.SetDefaultValues(int32(numberOfInitialDataAbstractionUnits))
@@ -61,8 +65,9 @@ func ReadDataAbstractionSetFromDistancesFile(filePath string, numberOfInitialDat
 	for {
 		read, err := reader.ReadString('\n')
 		if err != nil && err != io.EOF {
-			break
+			return dataAbstractionSet, ErrorHandling.NewFileRowError(filePath, rowNumber+1, ErrorHandling.ErrFileRead, err.Error())
 		}
+		rowNumber++
 
 		read = strings.TrimSpace(read)
 
@@ -82,19 +87,23 @@ func ReadDataAbstractionSetFromDistancesFile(filePath string, numberOfInitialDat
 		}
 
 		if dataAbstractionUnitNumber%5000 == 0 && dataAbstractionUnitNumber != 0 {
-			fmt.Println("Current number of data abstraction units read:", dataAbstractionUnitNumber, ", more to read...")
+			Logging.Info("More data abstraction units to read...", Logging.Fields{"number_of_data_abstraction_units_read": dataAbstractionUnitNumber})
 		}
 
 		var classLabelNumber int64
 		classLabelNumber, _ = strconv.ParseInt(readNumbers[0], 10, 32)
 		if classLabelNumber > int64(maximumClassLabelNumber) {
-			panic("Not finished successfully. Not enough colours specified for class label numbers.")
+			return dataAbstractionSet, ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrNotEnoughColours, fmt.Sprintf("class label number %d is more than %d", classLabelNumber, maximumClassLabelNumber))
 		}
 
 		var dataAbstractionUnit DataAbstraction.DataAbstractionUnit
 		dataAbstractionUnit.SetDefaultValues()
 		dataAbstractionUnit.ClassLabelNumber = int32(classLabelNumber)
 
+		if len(readNumbers) <= int(numberOfInitialDataAbstractionUnits) {
+			return dataAbstractionSet, ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrMalformedFile, fmt.Sprintf("%d distances found but %d are needed", len(readNumbers)-1, numberOfInitialDataAbstractionUnits))
+		}
+
 		for i := 1; i <= int(numberOfInitialDataAbstractionUnits); i++ {
 			dataAbstractionSet.DistancesBeforeTransformation[dataAbstractionUnitNumber][i-1], _ = strconv.ParseFloat(readNumbers[i], 64)
 		}
@@ -108,19 +117,21 @@ func ReadDataAbstractionSetFromDistancesFile(filePath string, numberOfInitialDat
 		}
 	}
 
-	return dataAbstractionSet
+	return dataAbstractionSet, nil
 }
 
-func ReadDataAbstractionSetFromMultiDimensionalDataFile(filePath string, numberOfInitialDataAbstractionUnits int32, maximumClassLabelNumber int32) DataAbstraction.DataAbstractionSet {
+func ReadDataAbstractionSetFromMultiDimensionalDataFile(filePath string, numberOfInitialDataAbstractionUnits int32, maximumClassLabelNumber int32) (DataAbstraction.DataAbstractionSet, error) {
 	var dataAbstractionSet DataAbstraction.DataAbstractionSet
 
 	file, err := os.Open(filePath)
 
 	if err != nil {
-		panic("File read error")
+		return dataAbstractionSet, ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, err.Error())
 	}
+	defer file.Close()
 
 	reader := bufio.NewReader(file)
+	rowNumber := 0
 
 	dataAbstractionSet.SetDefaultValues(int32(numberOfInitialDataAbstractionUnits))
 
@@ -129,8 +140,9 @@ func ReadDataAbstractionSetFromMultiDimensionalDataFile(filePath string, numberO
 	for {
 		read, err := reader.ReadString('\n')
 		if err != nil && err != io.EOF {
-			break
+			return dataAbstractionSet, ErrorHandling.NewFileRowError(filePath, rowNumber+1, ErrorHandling.ErrFileRead, err.Error())
 		}
+		rowNumber++
 
 		read = strings.TrimSpace(read)
 		if len(read) == 0 {
@@ -147,7 +159,7 @@ func ReadDataAbstractionSetFromMultiDimensionalDataFile(filePath string, numberO
 		}
 
 		if dataAbstractionUnitNumber%5000 == 0 && dataAbstractionUnitNumber != 0 {
-			fmt.Println("Current number of data abstraction units read:", dataAbstractionUnitNumber, ", more to read...")
+			Logging.Info("More data abstraction units to read...", Logging.Fields{"number_of_data_abstraction_units_read": dataAbstractionUnitNumber})
 		}
 
 		readNumbers := strings.Split(read, ",")
@@ -155,7 +167,7 @@ func ReadDataAbstractionSetFromMultiDimensionalDataFile(filePath string, numberO
 		var classLabelNumber int64
 		classLabelNumber, _ = strconv.ParseInt(readNumbers[0], 10, 32)
 		if classLabelNumber > int64(maximumClassLabelNumber) {
-			panic("Not finished successfully. Not enough colours specified for class label numbers.")
+			return dataAbstractionSet, ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrNotEnoughColours, fmt.Sprintf("class label number %d is more than %d", classLabelNumber, maximumClassLabelNumber))
 		}
 
 		var dataAbstractionUnit DataAbstraction.DataAbstractionUnit
@@ -177,15 +189,15 @@ func ReadDataAbstractionSetFromMultiDimensionalDataFile(filePath string, numberO
 		}
 	}
 
-	return dataAbstractionSet
+	return dataAbstractionSet, nil
 }
 
-func WriteEmbeddingToFile(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbstraction.DataAbstractionUnitVisibility, filePath string, colouring int32, dataAbstractionSet *DataAbstraction.DataAbstractionSet, coloursList []string) {
+func WriteEmbeddingToFile(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbstraction.DataAbstractionUnitVisibility, filePath string, colouring int32, dataAbstractionSet *DataAbstraction.DataAbstractionSet, coloursList []string, shufflingRandomSeed int64) error {
 	numberOfDataAbstractionUnits := int32(len(dataAbstractionUnitVisibilitiesToBeShuffled))
 	dataAbstractionUnitVisibilities := make([]*DataAbstraction.DataAbstractionUnitVisibility, numberOfDataAbstractionUnits)
 	copy(dataAbstractionUnitVisibilities, dataAbstractionUnitVisibilitiesToBeShuffled)
 
-	randomGenerator := rand.New(rand.NewSource(849662123548415231))
+	randomGenerator := rand.New(rand.NewSource(shufflingRandomSeed))
 	randomGenerator.Shuffle(len(dataAbstractionUnitVisibilities), func(i, j int) {
 		dataAbstractionUnitVisibilities[i], dataAbstractionUnitVisibilities[j] = dataAbstractionUnitVisibilities[j], dataAbstractionUnitVisibilities[i]
 	})
@@ -206,7 +218,7 @@ func WriteEmbeddingToFile(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbs
 			y := dataAbstractionUnitVisibility.VisualSpaceCoordinates[j][1]
 
 			if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
-				panic("Not finished successfully. Unstable floating point calculations.")
+				return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrUnstableFloatingPoint, fmt.Sprintf("data abstraction unit %d has coordinates (%g, %g)", dataAbstractionUnitVisibility.DataAbstractionUnitNumber, x, y))
 			}
 
 			xLow = math.Min(xLow, x)
@@ -236,25 +248,25 @@ func WriteEmbeddingToFile(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbs
 
 	for i = 0; i < int32(len(coloursList)); i++ {
 		colour := coloursList[i]
-		if colour[0] != '#' && len(colour) != 7 {
-			panic("Not finished successfully.")
+		if len(colour) != 7 || colour[0] != '#' {
+			return fmt.Errorf("%w: colour %q is not of the form #RRGGBB", ErrorHandling.ErrInconsistentSpecification, colour)
 		}
 
 		var err error
 		var red, green, blue int64
 		red, err = strconv.ParseInt(colour[1:3], 16, 16)
 		if err != nil {
-			panic("Not finished successfully.")
+			return fmt.Errorf("%w: colour %q is not of the form #RRGGBB", ErrorHandling.ErrInconsistentSpecification, colour)
 		}
 
 		green, err = strconv.ParseInt(colour[3:5], 16, 16)
 		if err != nil {
-			panic("Not finished successfully.")
+			return fmt.Errorf("%w: colour %q is not of the form #RRGGBB", ErrorHandling.ErrInconsistentSpecification, colour)
 		}
 
 		blue, err = strconv.ParseInt(colour[5:7], 16, 16)
 		if err != nil {
-			panic("Not finished successfully.")
+			return fmt.Errorf("%w: colour %q is not of the form #RRGGBB", ErrorHandling.ErrInconsistentSpecification, colour)
 		}
 
 		colours[i] = [3]float64{float64(red) / 255.0, float64(green) / 255.0, float64(blue) / 255.0}
@@ -380,20 +392,127 @@ func WriteEmbeddingToFile(dataAbstractionUnitVisibilitiesToBeShuffled []*DataAbs
 		}
 	}
 
-	file, _ := os.Create(filePath)
+	file, err := os.Create(filePath)
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+	file.Chmod(Chmod)
+	err = context.EncodePNG(file)
+	file.Close()
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+
+	return nil
+}
+
+func WriteValueColouredPositionsToFile(positions [][2]float64, values []float64, filePath string) error {
+	var xLow float64 = math.Inf(1)
+	var xHigh float64 = math.Inf(-1)
+
+	var yLow float64 = math.Inf(1)
+	var yHigh float64 = math.Inf(-1)
+
+	var valueHigh float64 = 0
+
+	for i := 0; i < len(positions); i++ {
+		x := positions[i][0]
+		y := positions[i][1]
+
+		if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
+			return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrUnstableFloatingPoint, fmt.Sprintf("position %d is (%g, %g)", i, x, y))
+		}
+
+		xLow = math.Min(xLow, x)
+		xHigh = math.Max(xHigh, x)
+		yLow = math.Min(yLow, y)
+		yHigh = math.Max(yHigh, y)
+		valueHigh = math.Max(valueHigh, values[i])
+	}
+
+	var contextWidth int = 2000
+	var contextHeight int = 2000
+
+	multiplicationFactor := (float64(contextWidth) / (xHigh - xLow))
+	multiplicationFactor = math.Min(multiplicationFactor, (float64(contextHeight) / (yHigh - yLow)))
+	if math.IsInf(multiplicationFactor, 0) || math.IsNaN(multiplicationFactor) {
+		multiplicationFactor = 1
+	}
+
+	var marginX float64 = 20
+	var marginY float64 = 20
+
+	contextWidth += int(marginX * 2)
+	contextHeight += int(marginY * 2)
+
+	lowColour := [3]float64{0.17, 0.48, 0.71}
+	middleColour := [3]float64{1, 1, 0.75}
+	highColour := [3]float64{0.84, 0.1, 0.11}
+
+	context := gg.NewContext(contextWidth, contextHeight)
+	context.SetRGB(1, 1, 1)
+	context.DrawRectangle(0, 0, float64(contextWidth), float64(contextHeight))
+	context.Fill()
+
+	order := make([]int, len(positions))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] < values[order[j]]
+	})
+
+	for _, i := range order {
+		fraction := 0.0
+		if valueHigh > 0 {
+			fraction = values[i] / valueHigh
+		}
+
+		var colour [3]float64
+		for k := 0; k < 3; k++ {
+			if fraction < 0.5 {
+				colour[k] = lowColour[k] + (middleColour[k]-lowColour[k])*fraction*2
+			} else {
+				colour[k] = middleColour[k] + (highColour[k]-middleColour[k])*(fraction-0.5)*2
+			}
+		}
+
+		x := (positions[i][0]-xLow)*multiplicationFactor + marginX
+		y := (positions[i][1]-yLow)*multiplicationFactor + marginY
+
+		context.SetRGB(colour[0], colour[1], colour[2])
+		context.DrawCircle(x, y, 15)
+		context.Fill()
+		context.SetRGB(0, 0, 0)
+		context.DrawCircle(x, y, 15)
+		context.SetLineWidth(2.0)
+		context.Stroke()
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
 	file.Chmod(Chmod)
-	context.EncodePNG(file)
+	err = context.EncodePNG(file)
 	file.Close()
+	if err != nil {
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileWrite, err.Error())
+	}
+
+	return nil
 }
 
-func ReadImagesFileGrayscaleSingleChannel(filePath string, dataAbstractionSet *DataAbstraction.DataAbstractionSet, imageWidth int32, imagesFileHasClassLabelNumbers bool) {
+func ReadImagesFileGrayscaleSingleChannel(filePath string, dataAbstractionSet *DataAbstraction.DataAbstractionSet, imageWidth int32, imagesFileHasClassLabelNumbers bool) error {
 	file, err := os.Open(filePath)
 
 	if err != nil {
-		panic("File read error")
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, err.Error())
 	}
+	defer file.Close()
 
 	reader := bufio.NewReader(file)
+	rowNumber := 0
 
 	numberOfDataAbstractionUnits := int32(len(dataAbstractionSet.DataAbstractionUnits))
 
@@ -402,8 +521,9 @@ func ReadImagesFileGrayscaleSingleChannel(filePath string, dataAbstractionSet *D
 	for {
 		read, err := reader.ReadString('\n')
 		if err != nil && err != io.EOF {
-			break
+			return ErrorHandling.NewFileRowError(filePath, rowNumber+1, ErrorHandling.ErrFileRead, err.Error())
 		}
+		rowNumber++
 
 		read = strings.TrimSpace(read)
 		if len(read) == 0 {
@@ -436,11 +556,11 @@ func ReadImagesFileGrayscaleSingleChannel(filePath string, dataAbstractionSet *D
 		dataAbstractionUnit.ImageHeight = int32(len(readNumbers)) / dataAbstractionUnit.ImageWidth
 
 		if imageWidth == -1 && dataAbstractionUnit.ImageWidth != dataAbstractionUnit.ImageHeight {
-			panic("Not finished successfully.")
+			return ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrMalformedFile, "the image is not square and no image width is specified")
 		}
 
 		if dataAbstractionUnit.ImageWidth*dataAbstractionUnit.ImageHeight != int32(len(readNumbers)) {
-			panic("Not finished successfully.")
+			return ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrMalformedFile, fmt.Sprintf("%d pixel values do not fit an image of width %d", len(readNumbers), dataAbstractionUnit.ImageWidth))
 		}
 
 		dataAbstractionUnit.ImageGrayscale = make([]uint8, dataAbstractionUnit.ImageWidth*dataAbstractionUnit.ImageHeight)
@@ -448,7 +568,7 @@ func ReadImagesFileGrayscaleSingleChannel(filePath string, dataAbstractionSet *D
 		for i := 0; i < len(readNumbers); i++ {
 			number, err := strconv.ParseInt(readNumbers[i], 10, 16)
 			if err != nil {
-				panic("Not finished successfully.")
+				return ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrMalformedFile, "could not parse pixel value "+strconv.Quote(readNumbers[i]))
 			}
 			dataAbstractionUnit.ImageGrayscale[i] = uint8(number)
 		}
@@ -459,18 +579,22 @@ func ReadImagesFileGrayscaleSingleChannel(filePath string, dataAbstractionSet *D
 	}
 
 	if dataAbstractionUnitNumber < numberOfDataAbstractionUnits-1 {
-		panic("Not finished successfully")
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, fmt.Sprintf("%d images found but %d are needed", dataAbstractionUnitNumber+1, numberOfDataAbstractionUnits))
 	}
+
+	return nil
 }
 
-func ReadImagesFileRedGreenBlueChannels(filePath string, dataAbstractionSet *DataAbstraction.DataAbstractionSet, imageWidth int32, imagesFileHasClassLabelNumbers bool) {
+func ReadImagesFileRedGreenBlueChannels(filePath string, dataAbstractionSet *DataAbstraction.DataAbstractionSet, imageWidth int32, imagesFileHasClassLabelNumbers bool) error {
 	file, err := os.Open(filePath)
 
 	if err != nil {
-		panic("File read error")
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrFileRead, err.Error())
 	}
+	defer file.Close()
 
 	reader := bufio.NewReader(file)
+	rowNumber := 0
 
 	numberOfDataAbstractionUnits := int32(len(dataAbstractionSet.DataAbstractionUnits))
 
@@ -479,8 +603,9 @@ func ReadImagesFileRedGreenBlueChannels(filePath string, dataAbstractionSet *Dat
 	for {
 		read, err := reader.ReadString('\n')
 		if err != nil && err != io.EOF {
-			break
+			return ErrorHandling.NewFileRowError(filePath, rowNumber+1, ErrorHandling.ErrFileRead, err.Error())
 		}
+		rowNumber++
 
 		read = strings.TrimSpace(read)
 		if len(read) == 0 {
@@ -513,11 +638,11 @@ func ReadImagesFileRedGreenBlueChannels(filePath string, dataAbstractionSet *Dat
 		dataAbstractionUnit.ImageHeight = int32(len(readNumbers)) / (dataAbstractionUnit.ImageWidth * 3)
 
 		if imageWidth == -1 && dataAbstractionUnit.ImageWidth != dataAbstractionUnit.ImageHeight {
-			panic("Not finished successfully.")
+			return ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrMalformedFile, "the image is not square and no image width is specified")
 		}
 
 		if dataAbstractionUnit.ImageWidth*dataAbstractionUnit.ImageHeight*3 != int32(len(readNumbers)) {
-			panic("Not finished successfully.")
+			return ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrMalformedFile, fmt.Sprintf("%d pixel values do not fit an image of width %d with 3 channels", len(readNumbers), dataAbstractionUnit.ImageWidth))
 		}
 
 		dataAbstractionUnit.ImageRGB = make([]uint8, dataAbstractionUnit.ImageWidth*dataAbstractionUnit.ImageHeight*3)
@@ -525,7 +650,7 @@ func ReadImagesFileRedGreenBlueChannels(filePath string, dataAbstractionSet *Dat
 		for i := 0; i < len(readNumbers); i++ {
 			number, err := strconv.ParseInt(readNumbers[i], 10, 16)
 			if err != nil {
-				panic("Not finished successfully.")
+				return ErrorHandling.NewFileRowError(filePath, rowNumber, ErrorHandling.ErrMalformedFile, "could not parse pixel value "+strconv.Quote(readNumbers[i]))
 			}
 			dataAbstractionUnit.ImageRGB[i] = uint8(number)
 		}
@@ -536,11 +661,13 @@ func ReadImagesFileRedGreenBlueChannels(filePath string, dataAbstractionSet *Dat
 	}
 
 	if dataAbstractionUnitNumber < numberOfDataAbstractionUnits-1 {
-		panic("Not finished successfully")
+		return ErrorHandling.NewFileError(filePath, ErrorHandling.ErrMalformedFile, fmt.Sprintf("%d images found but %d are needed", dataAbstractionUnitNumber+1, numberOfDataAbstractionUnits))
 	}
+
+	return nil
 }
 
-func WriteLegendFileHtml(filePath string, classLabels []string, coloursList []string) {
+func WriteLegendFileHtml(filePath string, classLabels []string, coloursList []string) error {
 	var radiusBig int32 = 16
 	var radiusSmall int32 = 8
 
@@ -582,15 +709,12 @@ func WriteLegendFileHtml(filePath string, classLabels []string, coloursList []st
 	html.WriteString("</body>\r\n")
 	html.WriteString("</html>\r\n")
 
-	bytes, _ := ioutil.ReadAll(strings.NewReader(html.String()))
-	ioutil.WriteFile(filePath, bytes, Chmod)
-
+	return WriteFile(filePath, []byte(html.String()))
 }
 
-func WriteShowFileHtml(outputDirectory string, dataAbstractionUnitVisibilities []*DataAbstraction.DataAbstractionUnitVisibility) {
+func WriteShowFileHtml(outputDirectory string, dataAbstractionUnitVisibilities []*DataAbstraction.DataAbstractionUnitVisibility) error {
 	var html strings.Builder
 	html.WriteString(WebUserInterface.WebUserInterfaceCode)
 
-	bytes, _ := ioutil.ReadAll(strings.NewReader(html.String()))
-	ioutil.WriteFile(filepath.Join(outputDirectory, "show.html"), bytes, Chmod)
+	return WriteFile(filepath.Join(outputDirectory, "show.html"), []byte(html.String()))
 }
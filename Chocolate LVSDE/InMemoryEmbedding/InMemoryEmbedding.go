@@ -0,0 +1,313 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package InMemoryEmbedding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataAbstraction"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/DataEmbedding"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"math"
+	"strconv"
+)
+
+type Options struct {
+	VisualDensityAdjustmentParameter                float64
+	NumberOfNeighboursForBuildingNeighbourhoodGraph int32
+	RandomSeed                                      int64
+	UseCosineDistance                               bool
+	PointsAreDistances                              bool
+	KeepIterationHistory                            bool
+	PhaseSchedule                                   DataEmbedding.PhaseSchedule
+	CoolingSchedule                                 DataEmbedding.CoolingScheduleConfiguration
+	ConvergenceCriterion                            *DataEmbedding.ConvergenceCriterion
+	RepulsiveForces                                 DataEmbedding.RepulsiveForceConfiguration
+	Multilevel                                      *DataEmbedding.MultilevelConfiguration
+	NearestNeighbours                               DataEmbedding.NearestNeighbourConfiguration
+	DistanceStorage                                 string
+	ProgressObservers                               []DataEmbedding.ProgressObserver
+}
+
+func DefaultOptions() Options {
+	var options Options
+	options.VisualDensityAdjustmentParameter = DataEmbedding.DefaultVisualDensityAdjustmentParameter
+	options.NumberOfNeighboursForBuildingNeighbourhoodGraph = 0
+	options.RandomSeed = DataEmbedding.DefaultRandomSeed
+	options.UseCosineDistance = false
+	options.PointsAreDistances = false
+	options.KeepIterationHistory = false
+	options.PhaseSchedule = DataEmbedding.DefaultPhaseSchedule()
+	options.CoolingSchedule = DataEmbedding.DefaultCoolingScheduleConfiguration()
+	options.RepulsiveForces = DataEmbedding.DefaultRepulsiveForceConfiguration()
+	options.NearestNeighbours = DataEmbedding.DefaultNearestNeighbourConfiguration()
+	options.DistanceStorage = DataEmbedding.DistanceStorageDense
+	return options
+}
+
+type Result struct {
+	Projections                                     [][][2]float64
+	Layers                                          []string
+	ClassLabelNumbers                               []int32
+	NumberOfIterations                              int
+	IsCancelled                                     bool
+	LastCompletedIteration                          int
+	NumberOfNeighboursForBuildingNeighbourhoodGraph int32
+	IterationHistory                                [][]*DataAbstraction.DataAbstractionUnitVisibility
+	EmbeddingDetails                                *DataAbstraction.EmbeddingDetails
+}
+
+func (result *Result) NumberOfGrayLayerDataAbstractionUnits() int {
+	numberOfGrayLayerDataAbstractionUnits := 0
+	for _, layer := range result.Layers {
+		if layer == "gray" {
+			numberOfGrayLayerDataAbstractionUnits++
+		}
+	}
+	return numberOfGrayLayerDataAbstractionUnits
+}
+
+func Embed(ctx context.Context, points [][]float64, labels []int32, options Options) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	dataAbstractionSet, err := NewDataAbstractionSet(points, labels, options.PointsAreDistances)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.DistanceStorage == DataEmbedding.DistanceStorageSparse {
+		if options.UseCosineDistance {
+			dataAbstractionSet.DistancesBeforeTransformationSource = DataAbstraction.DistanceSourceOriginalSpaceCosine
+		} else {
+			dataAbstractionSet.DistancesBeforeTransformationSource = DataAbstraction.DistanceSourceOriginalSpaceEuclidean
+		}
+	} else if !options.PointsAreDistances {
+		if options.UseCosineDistance {
+			err = dataAbstractionSet.ComputeDistancesBeforeTransformationCosine(ctx)
+		} else {
+			err = dataAbstractionSet.ComputeDistancesBeforeTransformationEuclidean(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	numberOfDataAbstractionUnits := int32(len(dataAbstractionSet.DataAbstractionUnits))
+
+	var dataEmbeddingTechniqueLVSDE DataEmbedding.DataEmbeddingTechniqueLVSDE
+	dataEmbeddingTechniqueLVSDE.VisualDensityAdjustmentParameter = options.VisualDensityAdjustmentParameter
+	dataEmbeddingTechniqueLVSDE.RandomSeed = options.RandomSeed
+	dataEmbeddingTechniqueLVSDE.PhaseSchedule = options.PhaseSchedule
+	dataEmbeddingTechniqueLVSDE.CoolingSchedule = DataEmbedding.NewCoolingSchedule(options.CoolingSchedule)
+	dataEmbeddingTechniqueLVSDE.ConvergenceCriterion = options.ConvergenceCriterion
+	dataEmbeddingTechniqueLVSDE.RepulsiveForces = options.RepulsiveForces
+	dataEmbeddingTechniqueLVSDE.Multilevel = options.Multilevel
+	dataEmbeddingTechniqueLVSDE.NearestNeighbours = options.NearestNeighbours
+	dataEmbeddingTechniqueLVSDE.DistanceStorage = options.DistanceStorage
+	dataEmbeddingTechniqueLVSDE.ProgressObservers = options.ProgressObservers
+	dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph = options.NumberOfNeighboursForBuildingNeighbourhoodGraph
+	if dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph <= 0 {
+		dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph = numberOfDataAbstractionUnits / 3
+	}
+	if dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph >= numberOfDataAbstractionUnits {
+		return nil, fmt.Errorf("%w: %d neighbours for building the neighbourhood graph requested for %d points", ErrorHandling.ErrInvalidInput, dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph, numberOfDataAbstractionUnits)
+	}
+	if math.IsNaN(options.VisualDensityAdjustmentParameter) || math.IsInf(options.VisualDensityAdjustmentParameter, 0) {
+		return nil, fmt.Errorf("%w: visual density adjustment parameter is %g", ErrorHandling.ErrInvalidInput, options.VisualDensityAdjustmentParameter)
+	}
+	if options.PhaseSchedule.IsSet() {
+		for i, numberOfIterationsOfPhase := range options.PhaseSchedule.NumbersOfIterationsOfPhases {
+			if numberOfIterationsOfPhase < 1 {
+				return nil, fmt.Errorf("%w: phase %d of the phase schedule has %d iterations", ErrorHandling.ErrInvalidInput, i+1, numberOfIterationsOfPhase)
+			}
+		}
+	}
+
+	switch options.CoolingSchedule.Type {
+	case "", DataEmbedding.CoolingScheduleTypeLinear, DataEmbedding.CoolingScheduleTypeCosine:
+	case DataEmbedding.CoolingScheduleTypeExponential:
+		minimumTemperatureRatio := options.CoolingSchedule.MinimumTemperatureRatio
+		if !(minimumTemperatureRatio > 0) || minimumTemperatureRatio > 1 {
+			return nil, fmt.Errorf("%w: minimum temperature ratio of the exponential cooling schedule is %g", ErrorHandling.ErrInvalidInput, minimumTemperatureRatio)
+		}
+	case DataEmbedding.CoolingScheduleTypeAdaptive:
+		shrinkFactor := options.CoolingSchedule.ShrinkFactor
+		if !(shrinkFactor > 0) || shrinkFactor >= 1 {
+			return nil, fmt.Errorf("%w: shrink factor of the adaptive cooling schedule is %g", ErrorHandling.ErrInvalidInput, shrinkFactor)
+		}
+		if options.CoolingSchedule.Patience < 1 {
+			return nil, fmt.Errorf("%w: patience of the adaptive cooling schedule is %d", ErrorHandling.ErrInvalidInput, options.CoolingSchedule.Patience)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown cooling schedule %q", ErrorHandling.ErrInvalidInput, options.CoolingSchedule.Type)
+	}
+
+	if options.RepulsiveForces.Method == DataEmbedding.RepulsiveForceMethodBarnesHut {
+		openingAngle := options.RepulsiveForces.OpeningAngle
+		if !(openingAngle > 0) || math.IsInf(openingAngle, 0) {
+			return nil, fmt.Errorf("%w: opening angle of the Barnes-Hut approximation is %g", ErrorHandling.ErrInvalidInput, openingAngle)
+		}
+	} else if options.RepulsiveForces.Method != "" && options.RepulsiveForces.Method != DataEmbedding.RepulsiveForceMethodExact {
+		return nil, fmt.Errorf("%w: unknown repulsive force method %q", ErrorHandling.ErrInvalidInput, options.RepulsiveForces.Method)
+	}
+	if options.NearestNeighbours.Method == DataEmbedding.NearestNeighbourMethodNNDescent {
+		terminationFraction := options.NearestNeighbours.TerminationFraction
+		if !(terminationFraction > 0) || terminationFraction >= 1 {
+			return nil, fmt.Errorf("%w: termination fraction of the nn_descent nearest neighbour search is %g", ErrorHandling.ErrInvalidInput, terminationFraction)
+		}
+	} else if options.NearestNeighbours.Method != "" && options.NearestNeighbours.Method != DataEmbedding.NearestNeighbourMethodExact {
+		return nil, fmt.Errorf("%w: unknown nearest neighbour method %q", ErrorHandling.ErrInvalidInput, options.NearestNeighbours.Method)
+	}
+	if options.DistanceStorage == DataEmbedding.DistanceStorageSparse {
+		if options.PointsAreDistances {
+			return nil, fmt.Errorf("%w: the sparse distance storage needs coordinates rather than distances", ErrorHandling.ErrInvalidInput)
+		}
+		if options.NumberOfNeighboursForBuildingNeighbourhoodGraph <= 0 {
+			return nil, fmt.Errorf("%w: the sparse distance storage needs an explicit number of neighbours for building the neighbourhood graph", ErrorHandling.ErrInvalidInput)
+		}
+	} else if options.DistanceStorage != "" && options.DistanceStorage != DataEmbedding.DistanceStorageDense {
+		return nil, fmt.Errorf("%w: unknown distance storage %q", ErrorHandling.ErrInvalidInput, options.DistanceStorage)
+	}
+	if options.Multilevel != nil {
+		if options.Multilevel.CoarsestLevelSize < 2 {
+			return nil, fmt.Errorf("%w: coarsest level size of the multilevel layout is %d", ErrorHandling.ErrInvalidInput, options.Multilevel.CoarsestLevelSize)
+		}
+		if options.Multilevel.NumberOfRefinementIterations < 1 {
+			return nil, fmt.Errorf("%w: number of refinement iterations of the multilevel layout is %d", ErrorHandling.ErrInvalidInput, options.Multilevel.NumberOfRefinementIterations)
+		}
+	}
+	if options.ConvergenceCriterion != nil {
+		relativeMeanDisplacementThreshold := options.ConvergenceCriterion.RelativeMeanDisplacementThreshold
+		if !(relativeMeanDisplacementThreshold > 0) || math.IsInf(relativeMeanDisplacementThreshold, 0) {
+			return nil, fmt.Errorf("%w: relative mean displacement threshold of the convergence criterion is %g", ErrorHandling.ErrInvalidInput, relativeMeanDisplacementThreshold)
+		}
+		if options.ConvergenceCriterion.NumberOfConvergedIterations < 1 {
+			return nil, fmt.Errorf("%w: number of converged iterations of the convergence criterion is %d", ErrorHandling.ErrInvalidInput, options.ConvergenceCriterion.NumberOfConvergedIterations)
+		}
+	}
+
+	err = dataEmbeddingTechniqueLVSDE.EmbedData(ctx, dataAbstractionSet)
+	if err != nil {
+		if errors.Is(err, ErrorHandling.ErrCancelled) && len(dataEmbeddingTechniqueLVSDE.EmbeddingDetails.EmbeddingIterations) > 0 {
+			return newResult(&dataEmbeddingTechniqueLVSDE, numberOfDataAbstractionUnits, options.KeepIterationHistory), err
+		}
+		return nil, err
+	}
+
+	return newResult(&dataEmbeddingTechniqueLVSDE, numberOfDataAbstractionUnits, options.KeepIterationHistory), nil
+}
+
+func newResult(dataEmbeddingTechniqueLVSDE *DataEmbedding.DataEmbeddingTechniqueLVSDE, numberOfDataAbstractionUnits int32, keepIterationHistory bool) *Result {
+	embeddingDetails := &dataEmbeddingTechniqueLVSDE.EmbeddingDetails
+	embeddingDetails.NumberOfInitialDataAbstractionUnits = strconv.Itoa(int(numberOfDataAbstractionUnits))
+	embeddingDetails.VisualDensityAdjustmentParameter = strconv.FormatFloat(dataEmbeddingTechniqueLVSDE.VisualDensityAdjustmentParameter, 'g', -1, 64)
+	embeddingDetails.NumberOfNeighboursForBuildingNeighbourhoodGraph = strconv.Itoa(int(dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph))
+	embeddingDetails.RandomSeed = strconv.FormatInt(dataEmbeddingTechniqueLVSDE.RandomSeed, 10)
+	embeddingDetails.PreliminaryToThirtyDimensionsUMAP = "false"
+	embeddingDetails.VersionOfUsedChocolateLVSDE = DataEmbedding.VersionOfChocolateLVSDE
+
+	result := new(Result)
+	result.NumberOfIterations = len(embeddingDetails.EmbeddingIterations)
+	result.IsCancelled = embeddingDetails.IsCancelled
+	result.LastCompletedIteration = len(embeddingDetails.EmbeddingIterations)
+	result.NumberOfNeighboursForBuildingNeighbourhoodGraph = dataEmbeddingTechniqueLVSDE.NumberOfNeighboursForBuildingNeighbourhoodGraph
+
+	lastIteration := embeddingDetails.EmbeddingIterations[len(embeddingDetails.EmbeddingIterations)-1]
+	result.Projections = make([][][2]float64, len(lastIteration))
+	result.Layers = make([]string, len(lastIteration))
+	result.ClassLabelNumbers = make([]int32, len(lastIteration))
+	for _, dataAbstractionUnitVisibility := range lastIteration {
+		i := dataAbstractionUnitVisibility.DataAbstractionUnitNumber
+		result.Projections[i] = dataAbstractionUnitVisibility.VisualSpaceCoordinates
+		result.Layers[i] = dataAbstractionUnitVisibility.Layer
+		result.ClassLabelNumbers[i] = dataAbstractionUnitVisibility.ClassLabelNumber
+	}
+
+	if keepIterationHistory {
+		result.IterationHistory = embeddingDetails.EmbeddingIterations
+	} else {
+		embeddingDetails.EmbeddingIterations = embeddingDetails.EmbeddingIterations[len(embeddingDetails.EmbeddingIterations)-1:]
+	}
+	result.EmbeddingDetails = embeddingDetails
+
+	return result
+}
+
+func NewDataAbstractionSet(points [][]float64, labels []int32, pointsAreDistances bool) (DataAbstraction.DataAbstractionSet, error) {
+	var dataAbstractionSet DataAbstraction.DataAbstractionSet
+
+	numberOfDataAbstractionUnits := len(points)
+	if numberOfDataAbstractionUnits <= 20 {
+		return dataAbstractionSet, fmt.Errorf("%w: %d points given but at least 21 are needed", ErrorHandling.ErrNotEnoughDataAbstractionUnits, numberOfDataAbstractionUnits)
+	}
+
+	if labels != nil && len(labels) != numberOfDataAbstractionUnits {
+		return dataAbstractionSet, fmt.Errorf("%w: %d labels given for %d points", ErrorHandling.ErrInvalidInput, len(labels), numberOfDataAbstractionUnits)
+	}
+
+	numberOfDimensions := len(points[0])
+	if pointsAreDistances {
+		numberOfDimensions = numberOfDataAbstractionUnits
+	}
+	if numberOfDimensions == 0 {
+		return dataAbstractionSet, fmt.Errorf("%w: points have no coordinates", ErrorHandling.ErrInvalidInput)
+	}
+
+	dataAbstractionSet.SetDefaultValues(int32(numberOfDataAbstractionUnits))
+	if pointsAreDistances {
+		dataAbstractionSet.DistancesBeforeTransformation = make([][]float64, numberOfDataAbstractionUnits)
+	}
+
+	for i := 0; i < numberOfDataAbstractionUnits; i++ {
+		if len(points[i]) != numberOfDimensions {
+			return dataAbstractionSet, fmt.Errorf("%w: point %d has %d values but %d are expected", ErrorHandling.ErrInvalidInput, i, len(points[i]), numberOfDimensions)
+		}
+
+		for j := 0; j < numberOfDimensions; j++ {
+			if math.IsNaN(points[i][j]) || math.IsInf(points[i][j], 0) {
+				return dataAbstractionSet, fmt.Errorf("%w: value %d of point %d is not finite", ErrorHandling.ErrInvalidInput, j, i)
+			}
+		}
+
+		var dataAbstractionUnit DataAbstraction.DataAbstractionUnit
+		dataAbstractionUnit.SetDefaultValues()
+		dataAbstractionUnit.DataAbstractionUnitNumber = int32(i)
+		dataAbstractionUnit.ClassLabelNumber = 0
+		if labels != nil {
+			if labels[i] < 0 {
+				return dataAbstractionSet, fmt.Errorf("%w: label of point %d is negative", ErrorHandling.ErrInvalidInput, i)
+			}
+			dataAbstractionUnit.ClassLabelNumber = labels[i]
+		}
+
+		if pointsAreDistances {
+			dataAbstractionSet.DistancesBeforeTransformation[i] = make([]float64, numberOfDataAbstractionUnits)
+			copy(dataAbstractionSet.DistancesBeforeTransformation[i], points[i])
+		} else {
+			dataAbstractionUnit.OriginalSpaceCoordinates = make([]float64, numberOfDimensions)
+			copy(dataAbstractionUnit.OriginalSpaceCoordinates, points[i])
+		}
+
+		dataAbstractionSet.DataAbstractionUnits[i] = dataAbstractionUnit
+	}
+
+	return dataAbstractionSet, nil
+}
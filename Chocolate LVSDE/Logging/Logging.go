@@ -0,0 +1,222 @@
+/*
+	Named "Chocolate LVSDE", this project (including but not limited to this file) is an implementation of LVSDE dimensionality reduction technique written in Go programming language (majority of the code volume) and Python programming language, sometimes interoperating through C programming language interface of Go as the intermediate language interface, in addition to some codes written in Javascript, CSS and HTML.
+
+	The github repository for this project is designated at https://github.com/farshad-barahimi-academic-codes/chocolate-lvsde
+
+	Copyright notice for this code (this implementation of LVSDE) and this file:
+	Copyright (c) 2022-2023 Farshad Barahimi. Licensed under the MIT license.
+
+	All codes in this project including but not limited to this file are written by Farshad Barahimi.
+
+	The purpose of writing this code is academic.
+
+	LVSDE stands for Layered Vertex Splitting Data Embedding.
+	For more information about LVSDE dimensionality reduction technique (algorithm) look at the following arXiv preprint:
+	Farshad Barahimi, "Multi-point dimensionality reduction to improve projection layout reliability",  arXiv:2101.06224v3, 2022.
+*/
+
+package Logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (level Level) String() string {
+	if level < LevelDebug || level > LevelError {
+		return "unknown"
+	}
+	return levelNames[level]
+}
+
+func ParseLevel(text string) (Level, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "warning" {
+		text = "warn"
+	}
+	for i, levelName := range levelNames {
+		if levelName == text {
+			return Level(i), nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q, expected one of %s", text, strings.Join(levelNames, ", "))
+}
+
+type Fields map[string]interface{}
+
+type Logger struct {
+	mutex              sync.Mutex
+	output             io.Writer
+	minimumLevel       Level
+	isJsonOutput       bool
+	specificationIndex int
+	stage              string
+	startTime          time.Time
+}
+
+func NewLogger(output io.Writer, minimumLevel Level, isJsonOutput bool) *Logger {
+	logger := new(Logger)
+	logger.output = output
+	logger.minimumLevel = minimumLevel
+	logger.isJsonOutput = isJsonOutput
+	logger.specificationIndex = -1
+	logger.startTime = time.Now()
+	return logger
+}
+
+var defaultLogger = NewLogger(os.Stdout, LevelInfo, false)
+
+func DefaultLogger() *Logger {
+	return defaultLogger
+}
+
+func Configure(output io.Writer, minimumLevel Level, isJsonOutput bool) {
+	defaultLogger.mutex.Lock()
+	defer defaultLogger.mutex.Unlock()
+	defaultLogger.output = output
+	defaultLogger.minimumLevel = minimumLevel
+	defaultLogger.isJsonOutput = isJsonOutput
+}
+
+func (logger *Logger) SetSpecificationIndex(specificationIndex int) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.specificationIndex = specificationIndex
+	logger.stage = ""
+	logger.startTime = time.Now()
+}
+
+func (logger *Logger) SetStage(stage string) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.stage = stage
+}
+
+func (logger *Logger) IsEnabled(level Level) bool {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	return level >= logger.minimumLevel
+}
+
+func (logger *Logger) Log(level Level, message string, fields Fields) {
+	logger.LogExternalRecord(time.Now(), level, "", message, fields)
+}
+
+func (logger *Logger) LogExternalRecord(recordTime time.Time, level Level, stage string, message string, fields Fields) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+
+	if level < logger.minimumLevel {
+		return
+	}
+
+	if stage == "" {
+		stage = logger.stage
+	}
+	elapsed := recordTime.Sub(logger.startTime)
+
+	var line string
+	if logger.isJsonOutput {
+		record := make(map[string]interface{}, len(fields)+6)
+		for key, value := range fields {
+			record[key] = value
+		}
+		record["time"] = recordTime.Format(time.RFC3339Nano)
+		record["level"] = level.String()
+		record["specification_index"] = nil
+		if logger.specificationIndex >= 0 {
+			record["specification_index"] = logger.specificationIndex
+		}
+		record["stage"] = stage
+		record["elapsed_seconds"] = elapsed.Seconds()
+		record["message"] = message
+
+		jsonBytes, err := json.Marshal(record)
+		if err != nil {
+			jsonBytes, _ = json.Marshal(map[string]interface{}{"time": record["time"], "level": "error", "message": "could not encode log record: " + err.Error()})
+		}
+		line = string(jsonBytes)
+	} else {
+		text := strings.Builder{}
+		text.WriteString(recordTime.Format("2006-01-02 15:04:05.000"))
+		text.WriteString(fmt.Sprintf(" %-5s", strings.ToUpper(level.String())))
+		if logger.specificationIndex >= 0 {
+			text.WriteString(fmt.Sprintf(" [specification %d]", logger.specificationIndex))
+		}
+		if stage != "" {
+			text.WriteString(" [" + stage + "]")
+		}
+		text.WriteString(fmt.Sprintf(" +%.3fs ", elapsed.Seconds()))
+		text.WriteString(message)
+
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := fields[key]
+			if floatValue, ok := value.(float64); ok {
+				value = strconv.FormatFloat(floatValue, 'g', 6, 64)
+			}
+			text.WriteString(fmt.Sprintf(" %s=%v", key, value))
+		}
+		line = text.String()
+	}
+
+	fmt.Fprintln(logger.output, line)
+}
+
+func SetSpecificationIndex(specificationIndex int) {
+	defaultLogger.SetSpecificationIndex(specificationIndex)
+}
+
+func SetStage(stage string) {
+	defaultLogger.SetStage(stage)
+}
+
+func IsEnabled(level Level) bool {
+	return defaultLogger.IsEnabled(level)
+}
+
+func Log(level Level, message string, fields Fields) {
+	defaultLogger.Log(level, message, fields)
+}
+
+func LogExternalRecord(recordTime time.Time, level Level, stage string, message string, fields Fields) {
+	defaultLogger.LogExternalRecord(recordTime, level, stage, message, fields)
+}
+
+func Debug(message string, fields Fields) {
+	defaultLogger.Log(LevelDebug, message, fields)
+}
+
+func Info(message string, fields Fields) {
+	defaultLogger.Log(LevelInfo, message, fields)
+}
+
+func Warn(message string, fields Fields) {
+	defaultLogger.Log(LevelWarn, message, fields)
+}
+
+func Error(message string, fields Fields) {
+	defaultLogger.Log(LevelError, message, fields)
+}
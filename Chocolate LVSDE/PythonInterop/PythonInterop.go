@@ -21,9 +21,101 @@ package PythonInterop
 // #include <Python.h>
 import "C"
 
-func RunPythonFunction(functionCode string, functionName string, functionParameter []float64, functionOutputSize int) []float64 {
+import (
+	"fmt"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/ErrorHandling"
+	"github.com/farshad-barahimi-academic-codes/chocolate-lvsde/Logging"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const LogRecordsVariableName = "logRecords"
+
+func RunPythonFunction(functionCode string, functionName string, functionParameter []float64, functionOutputSize int) ([]float64, error) {
 	functionParameterSize := len(functionParameter)
 
+	moduleObject, functionObject, err := loadPythonFunction(functionCode, functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	functionParameterObject := C.PyTuple_New(CastNumberFromToC(functionParameterSize))
+	//defer C.Py_DecRef(functionParameterObject)
+
+	for i := 0; i < functionParameterSize; i++ {
+		functionParameterTempObject := C.PyFloat_FromDouble(C.double(functionParameter[i]))
+		C.PyTuple_SetItem(functionParameterObject, CastNumberFromToC(i), functionParameterTempObject)
+	}
+
+	functionReturnObject := C.PyObject_CallObject(functionObject, functionParameterObject)
+	//defer C.Py_DecRef(functionReturnObject)
+
+	if functionReturnObject == nil {
+		C.PyErr_PrintEx(0)
+		C.PyErr_Clear()
+	}
+
+	logPythonLogRecords(moduleObject)
+
+	if functionReturnObject == nil {
+		return nil, fmt.Errorf("%w: %s raised an exception", ErrorHandling.ErrPythonFunctionFailed, functionName)
+	}
+
+	functionReturnSize := int(C.PyTuple_Size(functionReturnObject))
+	if functionReturnSize != functionOutputSize {
+		C.PyErr_Clear()
+		return nil, fmt.Errorf("%w: %s returned %d values but %d were expected", ErrorHandling.ErrPythonFunctionFailed, functionName, functionReturnSize, functionOutputSize)
+	}
+
+	functionOutput := make([]float64, functionOutputSize)
+
+	for i := 0; i < functionOutputSize; i++ {
+		functionOutputTempObject := C.PyTuple_GetItem(functionReturnObject, CastNumberFromToC(i))
+		functionOutput[i] = float64(C.PyFloat_AsDouble(functionOutputTempObject))
+		if C.PyErr_Occurred() != nil {
+			C.PyErr_Clear()
+			return nil, fmt.Errorf("%w: value %d returned by %s is not a number", ErrorHandling.ErrPythonFunctionFailed, i, functionName)
+		}
+	}
+
+	return functionOutput, nil
+}
+
+func RunPythonFunctionReturningStrings(functionCode string, functionName string) []string {
+	moduleObject, functionObject, err := loadPythonFunction(functionCode, functionName)
+	if err != nil {
+		return nil
+	}
+
+	functionReturnObject := C.PyObject_CallObject(functionObject, C.PyTuple_New(0))
+	if functionReturnObject == nil {
+		C.PyErr_PrintEx(0)
+		C.PyErr_Clear()
+	}
+
+	logPythonLogRecords(moduleObject)
+
+	if functionReturnObject == nil {
+		return nil
+	}
+
+	functionOutputSize := int(C.PyTuple_Size(functionReturnObject))
+	functionOutput := make([]string, 0, functionOutputSize)
+	for i := 0; i < functionOutputSize; i++ {
+		functionOutputText := C.PyUnicode_AsUTF8(C.PyTuple_GetItem(functionReturnObject, CastNumberFromToC(i)))
+		if functionOutputText == nil {
+			C.PyErr_Clear()
+			functionOutput = append(functionOutput, "")
+			continue
+		}
+		functionOutput = append(functionOutput, C.GoString(functionOutputText))
+	}
+
+	return functionOutput
+}
+
+func loadPythonFunction(functionCode string, functionName string) (*C.PyObject, *C.PyObject, error) {
 	//defer C.Py_Finalize()
 	C.Py_Initialize()
 
@@ -47,38 +139,62 @@ func RunPythonFunction(functionCode string, functionName string, functionParamet
 
 	functionCodeC := C.CString(functionCode)
 	//defer C.free(unsafe.Pointer(functionCodeC))
-	C.PyRun_String(functionCodeC, C.Py_file_input, emptyDictionary, moduleDictionaryObject)
-	//functionCodeObject := C.PyRun_String(functionCodeC, C.Py_file_input, emptyDictionary, moduleDictionaryObject)
+	functionCodeObject := C.PyRun_String(functionCodeC, C.Py_file_input, emptyDictionary, moduleDictionaryObject)
 	//defer C.Py_DecRef(functionCodeObject)
+	if functionCodeObject == nil {
+		C.PyErr_PrintEx(0)
+		C.PyErr_Clear()
+		return nil, nil, fmt.Errorf("%w: the code of %s could not be run", ErrorHandling.ErrPythonFunctionFailed, functionName)
+	}
 
 	functionNameC := C.CString(functionName)
 	//defer C.free(unsafe.Pointer(functionNameC))
 	functionObject := C.PyObject_GetAttrString(moduleObject, functionNameC)
 	//defer C.Py_DecRef(functionObject)
-
-	functionParameterObject := C.PyTuple_New(CastNumberFromToC(functionParameterSize))
-	//defer C.Py_DecRef(functionParameterObject)
-
-	for i := 0; i < functionParameterSize; i++ {
-		functionParameterTempObject := C.PyFloat_FromDouble(C.double(functionParameter[i]))
-		C.PyTuple_SetItem(functionParameterObject, CastNumberFromToC(i), functionParameterTempObject)
+	if functionObject == nil {
+		C.PyErr_Clear()
+		return nil, nil, fmt.Errorf("%w: %s is not defined", ErrorHandling.ErrPythonFunctionFailed, functionName)
 	}
 
-	functionReturnObject := C.PyObject_CallObject(functionObject, functionParameterObject)
-	//defer C.Py_DecRef(functionReturnObject)
+	return moduleObject, functionObject, nil
+}
 
-	if functionReturnObject == nil {
-		C.PyErr_PrintEx(0)
+func logPythonLogRecords(moduleObject *C.PyObject) {
+	logRecordsVariableNameC := C.CString(LogRecordsVariableName)
+	logRecordsObject := C.PyObject_GetAttrString(moduleObject, logRecordsVariableNameC)
+	if logRecordsObject == nil {
 		C.PyErr_Clear()
+		return
 	}
 
-	functionOutput := make([]float64, functionOutputSize)
+	numberOfLogRecords := int(C.PyList_Size(logRecordsObject))
+	for i := 0; i < numberOfLogRecords; i++ {
+		logRecordText := C.PyUnicode_AsUTF8(C.PyList_GetItem(logRecordsObject, CastNumberFromToC(i)))
+		if logRecordText == nil {
+			C.PyErr_Clear()
+			continue
+		}
+		logPythonLogRecord(C.GoString(logRecordText))
+	}
+}
 
-	for i := 0; i < functionOutputSize; i++ {
-		functionOutputTempObject := C.PyTuple_GetItem(functionReturnObject, CastNumberFromToC(i))
-		functionOutput[i] = float64(C.PyFloat_AsDouble(functionOutputTempObject))
+func logPythonLogRecord(logRecordText string) {
+	parts := strings.SplitN(logRecordText, "\t", 4)
+	if len(parts) != 4 {
+		Logging.Info(logRecordText, nil)
+		return
+	}
 
+	level, err := Logging.ParseLevel(parts[0])
+	if err != nil {
+		level = Logging.LevelInfo
 	}
 
-	return functionOutput
+	recordTime := time.Now()
+	timestampNanoseconds, err := strconv.ParseInt(parts[2], 10, 64)
+	if err == nil {
+		recordTime = time.Unix(0, timestampNanoseconds)
+	}
+
+	Logging.LogExternalRecord(recordTime, level, parts[1], parts[3], Logging.Fields{"source": "python"})
 }
@@ -3,9 +3,11 @@ module github.com/farshad-barahimi-academic-codes/chocolate-lvsde
 go 1.18
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/emirpasic/gods v1.18.1
 	github.com/fogleman/gg v1.3.0
 	gopkg.in/mgo.v2 v2.0.0-20190816093944-a6b53ec6cb22
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (